@@ -0,0 +1,187 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CategoryInfo represents a category with its aliases and hashtag mappings
+type CategoryInfo struct {
+	ID        uint              `json:"id"`
+	Name      string            `json:"name"`
+	Blocked   bool              `json:"blocked"`
+	ItemCount int64             `json:"itemCount"`
+	Aliases   []AliasInfo       `json:"aliases"`
+	Hashtags  map[string]string `json:"hashtags"` // platform -> hashtag
+}
+
+// AliasInfo represents an alternative name of a category
+type AliasInfo struct {
+	ID    uint   `json:"id"`
+	Alias string `json:"alias"`
+}
+
+// GetCategories returns all categories
+func GetCategories(c *gin.Context) {
+	categories, err := inventory.ListCategories()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch categories"})
+		return
+	}
+
+	var counts []struct {
+		CategoryID uint
+		Count      int64
+	}
+	database.Db.Table("feed_item_categories").
+		Select("category_id, count(*) as count").
+		Group("category_id").
+		Scan(&counts)
+	itemCounts := make(map[uint]int64)
+	for _, count := range counts {
+		itemCounts[count.CategoryID] = count.Count
+	}
+
+	result := []CategoryInfo{}
+	for _, category := range categories {
+		info := CategoryInfo{
+			ID:        category.ID,
+			Name:      category.Name,
+			Blocked:   category.Blocked,
+			ItemCount: itemCounts[category.ID],
+			Aliases:   []AliasInfo{},
+			Hashtags:  map[string]string{},
+		}
+		for _, alias := range category.Aliases {
+			info.Aliases = append(info.Aliases, AliasInfo{ID: alias.ID, Alias: alias.Alias})
+		}
+		for _, hashtag := range category.Hashtags {
+			info.Hashtags[hashtag.Platform] = hashtag.Hashtag
+		}
+		result = append(result, info)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateCategory changes the blocked flag and per-platform hashtags of a category.
+// Body: {"blocked": true, "hashtags": {"bluesky": "landscape"}}, an empty hashtag removes the mapping.
+func UpdateCategory(c *gin.Context) {
+	id, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Blocked  *bool             `json:"blocked"`
+		Hashtags map[string]string `json:"hashtags"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := inventory.UpdateCategory(id, body.Blocked, body.Hashtags); err != nil {
+		categoryError(c, err, "Failed to update category")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Category updated"})
+}
+
+// MergeCategory merges a category into another one. Body: {"into": 12}
+func MergeCategory(c *gin.Context) {
+	id, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Into uint `json:"into" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if body.Into == id {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot merge a category into itself"})
+		return
+	}
+
+	if err := inventory.MergeCategories(id, body.Into); err != nil {
+		categoryError(c, err, "Failed to merge categories")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Categories merged"})
+}
+
+// AddCategoryAlias adds an alias to a category. Body: {"alias": "landscapes"}
+func AddCategoryAlias(c *gin.Context) {
+	id, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Alias string `json:"alias" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if err := inventory.AddCategoryAlias(id, body.Alias); err != nil {
+		categoryError(c, err, "Failed to add alias")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alias added"})
+}
+
+// DeleteCategoryAlias removes an alias
+func DeleteCategoryAlias(c *gin.Context) {
+	categoryID, ok := parseCategoryID(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("aliasId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	deleted, err := inventory.DeleteCategoryAlias(categoryID, uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete alias"})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Alias not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alias deleted"})
+}
+
+func parseCategoryID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func categoryError(c *gin.Context, err error, message string) {
+	if err == gorm.ErrRecordNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": message + ": " + err.Error()})
+}
@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/interactions"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CommentInfo represents a comment in the moderation queue. The IP hash is
+// not exposed.
+type CommentInfo struct {
+	ID        uint      `json:"id"`
+	ItemName  string    `json:"itemName"`
+	Name      string    `json:"name"`
+	Website   string    `json:"website"`
+	Text      string    `json:"text"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// GetComments returns comments, newest first.
+// Query parameters: status, itemName
+func GetComments(c *gin.Context) {
+	comments, ok := queryComments(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, comments)
+}
+
+// ExportComments returns the same comments as GetComments as a JSON download
+func ExportComments(c *gin.Context) {
+	comments, ok := queryComments(c)
+	if !ok {
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="comments.json"`)
+	c.JSON(http.StatusOK, comments)
+}
+
+// UpdateComment sets the moderation state of a comment.
+// Body: {"status": "approved"}
+func UpdateComment(c *gin.Context) {
+	id, ok := parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || !interactions.IsCommentStatus(body.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Status must be pending, approved, spam or rejected"})
+		return
+	}
+
+	comment, err := interactions.SetCommentStatus(id, body.Status)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update comment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toCommentInfo(*comment))
+}
+
+// DeleteComment removes a comment
+func DeleteComment(c *gin.Context) {
+	id, ok := parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := interactions.DeleteComment(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete comment"})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted"})
+}
+
+func queryComments(c *gin.Context) ([]CommentInfo, bool) {
+	query := database.Db.Model(&models.Comment{})
+	if status := c.Query("status"); status != "" {
+		if !interactions.IsCommentStatus(status) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+			return nil, false
+		}
+		query = query.Where("status = ?", status)
+	}
+	if itemName := c.Query("itemName"); itemName != "" {
+		query = query.Where("item_name = ?", itemName)
+	}
+
+	var comments []models.Comment
+	if err := query.Order("created_at DESC").Find(&comments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch comments"})
+		return nil, false
+	}
+
+	result := []CommentInfo{}
+	for _, comment := range comments {
+		result = append(result, toCommentInfo(comment))
+	}
+	return result, true
+}
+
+func toCommentInfo(comment models.Comment) CommentInfo {
+	return CommentInfo{
+		ID:        comment.ID,
+		ItemName:  comment.ItemName,
+		Name:      comment.Name,
+		Website:   comment.Website,
+		Text:      comment.Text,
+		Status:    comment.Status,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
+
+func parseCommentID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
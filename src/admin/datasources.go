@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/gin-gonic/gin"
+)
+
+const maxOpmlSize = 5 << 20
+
+// DatasourceInfo represents a datasource and where it is managed
+type DatasourceInfo struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	FeedURL   string `json:"feedUrl,omitempty"`
+	Path      string `json:"path,omitempty"`
+	ItemType  string `json:"itemType"`
+	ManagedBy string `json:"managedBy"` // "config" or "database"
+}
+
+// GetDatasources returns all datasources
+func GetDatasources(c *gin.Context) {
+	result := []DatasourceInfo{}
+	for _, source := range inventory.Datasources() {
+		managedBy := "database"
+		if inventory.IsConfigDatasource(source.Name) {
+			managedBy = "config"
+		}
+		result = append(result, DatasourceInfo{
+			Name:      source.Name,
+			Type:      source.Type,
+			FeedURL:   source.FeedURL,
+			Path:      source.Path,
+			ItemType:  source.ItemType,
+			ManagedBy: managedBy,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportDatasourcesOpml returns all datasources as an OPML file
+func ExportDatasourcesOpml(c *gin.Context) {
+	c.Header("Content-Disposition", `attachment; filename="datasources.opml"`)
+	c.XML(http.StatusOK, inventory.ExportOpml())
+}
+
+// ImportDatasourcesOpml imports datasources from an OPML file, either as the
+// raw request body or as multipart "file". Use ?dryRun=true to only get the diff.
+func ImportDatasourcesOpml(c *gin.Context) {
+	var reader io.Reader = c.Request.Body
+	if file, err := c.FormFile("file"); err == nil {
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+			return
+		}
+		defer opened.Close()
+		reader = opened
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxOpmlSize))
+	if err != nil || len(data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "OPML body required"})
+		return
+	}
+
+	diff, err := inventory.ImportOpml(data, c.Query("dryRun") == "true")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid OPML: " + err.Error()})
+		return
+	}
+
+	if !diff.DryRun {
+		for _, change := range diff.Added {
+			inventory.SyncDatasourceInBackground(change.Name)
+		}
+		for _, change := range diff.Updated {
+			inventory.SyncDatasourceInBackground(change.Name)
+		}
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// DeleteDatasource removes a database managed datasource
+func DeleteDatasource(c *gin.Context) {
+	name := c.Param("name")
+	if inventory.IsConfigDatasource(name) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Datasource is defined in the config file"})
+		return
+	}
+
+	deleted, err := inventory.DeleteStoredDatasource(name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete datasource"})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Datasource not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Datasource deleted"})
+}
@@ -6,8 +6,10 @@ import (
 
 	"github.com/LNA-DEV/HomePageCompanion/config"
 	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/interactions"
 	"github.com/LNA-DEV/HomePageCompanion/inventory"
 	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/LNA-DEV/HomePageCompanion/webpush"
 	"github.com/gin-gonic/gin"
 )
 
@@ -48,16 +50,39 @@ func RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	{
 		admin.GET("/auth/verify", VerifyAuth)
 		admin.GET("/stats", GetStats)
+		admin.GET("/datasources", GetDatasources)
+		admin.GET("/datasources/opml", ExportDatasourcesOpml)
+		admin.POST("/datasources/opml", ImportDatasourcesOpml)
+		admin.DELETE("/datasources/:name", DeleteDatasource)
 		admin.GET("/feeds", GetFeeds)
 		admin.GET("/feeds/:id", GetFeed)
 		admin.GET("/feeds/:id/items", GetFeedItems)
+		admin.GET("/items/:id/revisions", GetItemRevisions)
+		admin.GET("/duplicates", GetDuplicates)
+		admin.GET("/search", SearchItems)
+		admin.GET("/categories", GetCategories)
+		admin.PATCH("/categories/:id", UpdateCategory)
+		admin.POST("/categories/:id/merge", MergeCategory)
+		admin.POST("/categories/:id/aliases", AddCategoryAlias)
+		admin.DELETE("/categories/:id/aliases/:aliasId", DeleteCategoryAlias)
 		admin.GET("/publications", GetPublications)
 		admin.DELETE("/publications/:id", DeletePublication)
 		admin.GET("/interactions", GetInteractions)
 		admin.GET("/interactions/summary", GetInteractionsSummary)
+		admin.GET("/interactions/history", GetItemInteractionHistory)
+		admin.GET("/interactions/history/platforms", GetPlatformInteractionHistory)
+		admin.GET("/milestones", GetMilestones)
+		admin.GET("/comments", GetComments)
+		admin.GET("/comments/export", ExportComments)
+		admin.PATCH("/comments/:id", UpdateComment)
+		admin.DELETE("/comments/:id", DeleteComment)
+		admin.POST("/webpush/subscribe", webpush.AdminSubscribeHandler())
 		admin.GET("/subscribers", GetSubscribers)
 		admin.DELETE("/subscribers/:id", DeleteSubscriber)
 		admin.GET("/webmentions", GetWebmentions)
+		admin.GET("/security/bursts", GetBursts)
+		admin.DELETE("/security/bursts", RemoveBursts)
+		admin.POST("/privacy/erase", ErasePrivacyData)
 		admin.GET("/connections", GetConnections)
 	}
 }
@@ -77,7 +102,7 @@ func GetStats(c *gin.Context) {
 	database.Db.Model(&models.Interaction{}).Count(&stats.InteractionCount)
 	database.Db.Model(&models.NotificationSubscription{}).Count(&stats.SubscriberCount)
 	database.Db.Model(&models.Webmention{}).Count(&stats.WebmentionCount)
-	database.Db.Model(&models.NativeLike{}).Count(&stats.NativeLikeCount)
+	database.Db.Model(&models.NativeLike{}).Where("reaction = ?", interactions.NativeReactionLike).Count(&stats.NativeLikeCount)
 
 	// Sum of all likes from interactions
 	database.Db.Model(&models.Interaction{}).Select("COALESCE(SUM(like_count), 0)").Scan(&stats.TotalLikes)
@@ -185,16 +210,68 @@ func GetFeedItems(c *gin.Context) {
 	})
 }
 
+// GetItemRevisions returns the change history of a feed item
+func GetItemRevisions(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	item, err := inventory.GetFeedItemByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+	if item == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return
+	}
+
+	revisions, err := inventory.ListRevisions(item.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch revisions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"item":      item,
+		"revisions": revisions,
+	})
+}
+
+// GetDuplicates returns pairs of feed items with near-identical images
+func GetDuplicates(c *gin.Context) {
+	threshold, err := strconv.Atoi(c.DefaultQuery("threshold", strconv.Itoa(inventory.DuplicateThreshold)))
+	if err != nil || threshold < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid threshold"})
+		return
+	}
+
+	pairs, err := inventory.FindDuplicates(threshold)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find duplicates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, pairs)
+}
+
 // GetPublications returns all auto-uploaded items
 func GetPublications(c *gin.Context) {
 	var items []models.AutoUploadItem
 
 	// Optional filtering
 	platform := c.Query("platform")
+	targetName := c.Query("target")
 	query := database.Db.Model(&models.AutoUploadItem{})
 	if platform != "" {
 		query = query.Where("platform = ?", platform)
 	}
+	if targetName != "" {
+		query = query.Where("target_name = ?", targetName)
+	}
 
 	query.Order("created_at DESC").Find(&items)
 	c.JSON(http.StatusOK, items)
@@ -266,7 +343,7 @@ func GetInteractionsSummary(c *gin.Context) {
 		Scan(&summary.TotalLikes)
 
 	// Total native likes
-	database.Db.Model(&models.NativeLike{}).Count(&summary.TotalNativeLikes)
+	database.Db.Model(&models.NativeLike{}).Where("reaction = ?", interactions.NativeReactionLike).Count(&summary.TotalNativeLikes)
 
 	// Breakdown by platform
 	summary.PlatformBreakdown = make(map[string]int64)
@@ -302,6 +379,7 @@ func GetSubscribers(c *gin.Context) {
 	type SafeSubscriber struct {
 		ID        uint   `json:"id"`
 		Endpoint  string `json:"endpoint"`
+		Admin     bool   `json:"admin"`
 		CreatedAt string `json:"createdAt"`
 	}
 
@@ -310,6 +388,7 @@ func GetSubscribers(c *gin.Context) {
 		result = append(result, SafeSubscriber{
 			ID:        sub.ID,
 			Endpoint:  sub.Endpoint,
+			Admin:     sub.Admin,
 			CreatedAt: sub.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		})
 	}
@@ -346,6 +425,22 @@ func GetWebmentions(c *gin.Context) {
 	c.JSON(http.StatusOK, webmentions)
 }
 
+// GetMilestones returns the recorded milestones, newest first.
+// Query parameters: kind
+func GetMilestones(c *gin.Context) {
+	query := database.Db.Order("created_at DESC")
+	if kind := c.Query("kind"); kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	var milestones []models.Milestone
+	if err := query.Find(&milestones).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch milestones"})
+		return
+	}
+	c.JSON(http.StatusOK, milestones)
+}
+
 // GetConnections returns all configured connections (sanitized)
 func GetConnections(c *gin.Context) {
 	// Build a map of target name to platform
@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/interactions"
+	"github.com/gin-gonic/gin"
+)
+
+var historyIntervals = map[string]time.Duration{
+	"hour": time.Hour,
+	"day":  24 * time.Hour,
+	"week": 7 * 24 * time.Hour,
+}
+
+// GetItemInteractionHistory returns the interaction time series of one item per platform.
+// Query parameters: itemName (required), metric, interval (hour|day|week), from, to
+func GetItemInteractionHistory(c *gin.Context) {
+	itemName := c.Query("itemName")
+	if itemName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "itemName required"})
+		return
+	}
+
+	metric, from, to, interval, ok := parseHistoryQuery(c)
+	if !ok {
+		return
+	}
+
+	series, err := interactions.ItemHistory(itemName, metric, from, to, interval)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// GetPlatformInteractionHistory returns the total interactions per platform over time.
+// Query parameters: metric, interval (hour|day|week), from, to
+func GetPlatformInteractionHistory(c *gin.Context) {
+	metric, from, to, interval, ok := parseHistoryQuery(c)
+	if !ok {
+		return
+	}
+
+	series, err := interactions.PlatformHistory(metric, from, to, interval)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// parseHistoryQuery defaults to daily likes of the last 30 days
+func parseHistoryQuery(c *gin.Context) (metric string, from time.Time, to time.Time, interval time.Duration, ok bool) {
+	metric = c.DefaultQuery("metric", interactions.MetricLikes)
+
+	interval, found := historyIntervals[c.DefaultQuery("interval", "day")]
+	if !found {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interval, use hour, day or week"})
+		return "", from, to, 0, false
+	}
+
+	to = time.Now()
+	from = to.AddDate(0, 0, -30)
+	for param, target := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			parsed, err = time.Parse("2006-01-02", value)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + " date"})
+			return "", from, to, 0, false
+		}
+		*target = parsed
+	}
+
+	return metric, from, to, interval, true
+}
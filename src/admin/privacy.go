@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/LNA-DEV/HomePageCompanion/interactions"
+	"github.com/LNA-DEV/HomePageCompanion/security"
+	"github.com/gin-gonic/gin"
+)
+
+// ErasePrivacyData deletes the native likes and comments of a visitor for a
+// data subject request. The IP address is hashed with every active salt.
+// Body: {"ip": "203.0.113.7"} or {"ipHash": "<hash>"}
+func ErasePrivacyData(c *gin.Context) {
+	var body struct {
+		IP     string `json:"ip"`
+		IPHash string `json:"ipHash"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || (body.IP == "") == (body.IPHash == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Either ip or ipHash required"})
+		return
+	}
+
+	ipHashes := []string{body.IPHash}
+	if body.IP != "" {
+		ipHashes = security.Hashes(security.HashIPVersions(body.IP))
+	}
+
+	result, err := interactions.EraseIPHash(ipHashes...)
+	if err != nil {
+		log.Printf("Error erasing data of IP hash: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to erase data"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
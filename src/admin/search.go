@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/LNA-DEV/HomePageCompanion/syndication"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchResult represents a matching item with its publication status
+type SearchResult struct {
+	Item         models.FeedItem     `json:"item"`
+	Feed         string              `json:"feed"`
+	Score        float64             `json:"score"`
+	Publications []PublicationStatus `json:"publications"`
+}
+
+// PublicationStatus tells whether an item was published to a target
+type PublicationStatus struct {
+	Platform    string     `json:"platform"`
+	TargetName  string     `json:"targetName"`
+	Published   bool       `json:"published"`
+	URL         string     `json:"url,omitempty"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+}
+
+// SearchItems runs a full-text search over feed items
+func SearchItems(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter q required"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	matches, err := inventory.SearchItems(query, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Search failed"})
+		return
+	}
+
+	feeds, _ := inventory.ListFeeds(false)
+	feedNames := make(map[uint]string)
+	for _, feed := range feeds {
+		feedNames[feed.ID] = feed.FeedName
+	}
+
+	// Targets each datasource is connected to
+	targetPlatforms := make(map[string]string)
+	for _, target := range config.Data.Targets {
+		targetPlatforms[target.Name] = target.Platform
+	}
+	sourceTargets := make(map[string][]string)
+	for _, conn := range config.Data.Connections {
+		if _, ok := targetPlatforms[conn.TargetName]; ok {
+			sourceTargets[conn.SourceName] = append(sourceTargets[conn.SourceName], conn.TargetName)
+		}
+	}
+
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match.Item.Title)
+	}
+	var publications []models.AutoUploadItem
+	database.Db.Where("item_name IN ?", names).Order("created_at").Find(&publications)
+	published := make(map[string]map[string]models.AutoUploadItem)
+	for _, publication := range publications {
+		if published[publication.ItemName] == nil {
+			published[publication.ItemName] = make(map[string]models.AutoUploadItem)
+		}
+		published[publication.ItemName][publication.TargetName] = publication
+	}
+
+	results := []SearchResult{}
+	for _, match := range matches {
+		feed := feedNames[match.Item.FeedID]
+		result := SearchResult{
+			Item:         match.Item,
+			Feed:         feed,
+			Score:        match.Score,
+			Publications: []PublicationStatus{},
+		}
+
+		seen := make(map[string]bool)
+		for _, targetName := range sourceTargets[feed] {
+			if seen[targetName] {
+				continue
+			}
+			seen[targetName] = true
+			result.Publications = append(result.Publications, publicationStatus(targetName, targetPlatforms[targetName], published[match.Item.Title]))
+		}
+		for targetName, publication := range published[match.Item.Title] {
+			if !seen[targetName] {
+				seen[targetName] = true
+				result.Publications = append(result.Publications, publicationStatus(targetName, publication.Platform, published[match.Item.Title]))
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+func publicationStatus(targetName string, platform string, published map[string]models.AutoUploadItem) PublicationStatus {
+	status := PublicationStatus{Platform: platform, TargetName: targetName}
+	if publication, ok := published[targetName]; ok {
+		status.Published = true
+		status.URL = syndication.CanonicalURL(publication)
+		status.PublishedAt = &publication.CreatedAt
+	}
+	return status
+}
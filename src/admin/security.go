@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/interactions"
+	"github.com/gin-gonic/gin"
+)
+
+// GetBursts returns spans with suspiciously many native likes.
+// Query parameters: hours (default 24), window in minutes (default 10), threshold (default 20)
+func GetBursts(c *gin.Context) {
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", "24"))
+	if err != nil || hours < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hours"})
+		return
+	}
+	window, err := strconv.Atoi(c.DefaultQuery("window", "10"))
+	if err != nil || window < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid window"})
+		return
+	}
+	threshold, err := strconv.Atoi(c.DefaultQuery("threshold", "20"))
+	if err != nil || threshold < 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid threshold"})
+		return
+	}
+
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+	bursts, err := interactions.FindBursts(since, time.Duration(window)*time.Minute, threshold)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find bursts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, bursts)
+}
+
+// RemoveBursts deletes the native likes of the given bursts.
+// Body: {"bursts": [{"kind": "ip", "key": "<ip hash>", "start": "...", "end": "..."}]}
+func RemoveBursts(c *gin.Context) {
+	var body struct {
+		Bursts []struct {
+			Kind  string    `json:"kind"`
+			Key   string    `json:"key"`
+			Start time.Time `json:"start"`
+			End   time.Time `json:"end"`
+		} `json:"bursts"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || len(body.Bursts) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Bursts required"})
+		return
+	}
+
+	var removed int64
+	for _, burst := range body.Bursts {
+		if burst.Key == "" || burst.End.Before(burst.Start) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid burst", "removed": removed})
+			return
+		}
+		count, err := interactions.RemoveBurst(burst.Kind, burst.Key, burst.Start, burst.End)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "removed": removed})
+			return
+		}
+		removed += count
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
@@ -13,6 +13,7 @@ import (
 
 	blueskyapi "github.com/LNA-DEV/HomePageCompanion/blue_sky_api"
 	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
 	"github.com/mmcdole/gofeed"
 )
 
@@ -51,10 +52,10 @@ func publishBlueskyEntry(entry *gofeed.Item, target config.Target, connection co
 
 	// Build caption
 	var caption strings.Builder
-	caption.WriteString(connection.Caption + "\n\n")
+	caption.WriteString(buildCaption(connection, entry) + "\n\n")
 
 	count := len(caption.String())
-	for _, tag := range entry.Categories {
+	for _, tag := range inventory.Hashtags(entry.Categories, target.Platform) {
 		tagText := "#" + tag
 		if count+len(tagText)+1 <= 300 {
 			caption.WriteString(tagText + " ")
@@ -73,11 +74,13 @@ func publishBlueskyEntry(entry *gofeed.Item, target config.Target, connection co
 		altText = "Alt not found"
 	}
 
-	// Download image
-	imageBytes, httpErr := downloadImage(mediaURL)
+	// Load image
+	source, _ := inventory.FindDatasource(connection.SourceName)
+	imageBytes, httpErr := inventory.LoadImage(mediaURL, source)
 	if httpErr != nil {
 		return httpErr
 	}
+	imageBytes = applyGpsPolicy(imageBytes, connection.Gps)
 
 	// Upload image to Bluesky
 	blobRef, httpErr := blueskyUploadImage(session.AccessJwt, imageBytes, altText)
@@ -149,7 +152,7 @@ func publishBlueskyEntry(entry *gofeed.Item, target config.Target, connection co
 	}
 
 	// Mark as published
-	if err := publishedEntry(entry.Title, target.Platform, &postResponse.CID, &postResponse.URI, nil); err != nil {
+	if err := publishedEntry(entry.Title, target, &postResponse.CID, &postResponse.URI, nil); err != nil {
 		return err
 	}
 
@@ -188,7 +191,7 @@ func extractFacets(text string) []map[string]interface{} {
 	var facets []map[string]interface{}
 
 	// Hashtag pattern
-	hashtagPattern := regexp.MustCompile(`#\w+`)
+	hashtagPattern := regexp.MustCompile(`#[\p{L}\p{N}_]+`)
 	for _, match := range hashtagPattern.FindAllStringIndex(text, -1) {
 		start, end := match[0], match[1]
 		tag := text[start+1 : end]
@@ -0,0 +1,76 @@
+package autouploader
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/mmcdole/gofeed"
+)
+
+// CaptionData is available to caption templates, e.g. "Shot on {{.Camera}}"
+type CaptionData struct {
+	Title       string
+	Camera      string
+	Lens        string
+	FocalLength float64
+	Aperture    float64
+	Exposure    string
+	ISO         int
+	Captured    *time.Time
+	Location    string // Empty when the connection strips GPS
+}
+
+// buildCaption renders the connection caption, expanding template fields
+// with the item's EXIF metadata.
+func buildCaption(connection config.Connection, entry *gofeed.Item) string {
+	if !strings.Contains(connection.Caption, "{{") {
+		return connection.Caption
+	}
+
+	tmpl, err := template.New(connection.Name).Parse(connection.Caption)
+	if err != nil {
+		log.Printf("Invalid caption template for %s: %v", connection.Name, err)
+		return connection.Caption
+	}
+
+	data := CaptionData{Title: entry.Title}
+
+	item, err := inventory.GetFeedItemByGUID(entry.GUID)
+	if err != nil {
+		log.Printf("Error loading item metadata for caption: %v", err)
+	}
+	if item != nil {
+		data.Camera = strings.TrimSpace(item.Exif.CameraMake + " " + item.Exif.CameraModel)
+		data.Lens = item.Exif.LensModel
+		data.FocalLength = item.Exif.FocalLength
+		data.Aperture = item.Exif.FNumber
+		data.Exposure = item.Exif.ExposureTime
+		data.ISO = item.Exif.ISO
+		data.Captured = item.Exif.CapturedAt
+
+		if item.Exif.Latitude != nil && item.Exif.Longitude != nil {
+			lat, long := *item.Exif.Latitude, *item.Exif.Longitude
+			switch connection.Gps {
+			case config.GpsStrip:
+				// Never expose the location
+			case config.GpsCoarse:
+				data.Location = fmt.Sprintf("%.2f, %.2f", math.Round(lat*100)/100, math.Round(long*100)/100)
+			default:
+				data.Location = fmt.Sprintf("%.5f, %.5f", lat, long)
+			}
+		}
+	}
+
+	var caption strings.Builder
+	if err := tmpl.Execute(&caption, data); err != nil {
+		log.Printf("Error rendering caption for %s: %v", connection.Name, err)
+		return connection.Caption
+	}
+	return caption.String()
+}
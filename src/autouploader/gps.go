@@ -0,0 +1,184 @@
+package autouploader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+	"math"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+)
+
+const (
+	exifGpsIfdTag    = 0x8825
+	exifGpsLatitude  = 0x0002
+	exifGpsLongitude = 0x0004
+	tiffRational     = 5
+)
+
+var (
+	exifHeader = []byte("Exif\x00\x00")
+	xmpHeader  = []byte("http://ns.adobe.com/xap/1.0/\x00")
+)
+
+// tiffTypeSizes maps TIFF field types to their size in bytes
+var tiffTypeSizes = map[uint16]uint32{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}
+
+// applyGpsPolicy strips or coarsens the location embedded in a JPEG before upload.
+// Other formats are returned unchanged.
+func applyGpsPolicy(data []byte, policy string) []byte {
+	if policy != config.GpsStrip && policy != config.GpsCoarse {
+		return data
+	}
+
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		log.Println("GPS policy only supports JPEG images, uploading unchanged")
+		return data
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			break
+		}
+		marker := data[i+1]
+
+		// Start of scan, the rest is image data
+		if marker == 0xDA {
+			break
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			break
+		}
+		segment := data[i:end]
+		payload := segment[4:]
+
+		if marker == 0xE1 {
+			switch {
+			case bytes.HasPrefix(payload, xmpHeader):
+				// XMP may repeat the location, drop it
+				i = end
+				continue
+			case bytes.HasPrefix(payload, exifHeader):
+				segment = append([]byte(nil), segment...)
+				rewriteGpsIfd(segment[4+len(exifHeader):], policy)
+			}
+		}
+
+		out.Write(segment)
+		i = end
+	}
+
+	out.Write(data[i:])
+	return out.Bytes()
+}
+
+// rewriteGpsIfd edits the GPS IFD of a TIFF structure in place.
+func rewriteGpsIfd(tiff []byte, policy string) {
+	if len(tiff) < 8 {
+		return
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return
+	}
+
+	gpsOffset, ok := findIfdEntry(tiff, order, order.Uint32(tiff[4:8]), exifGpsIfdTag)
+	if !ok {
+		return
+	}
+	gpsIfd := order.Uint32(tiff[gpsOffset+8 : gpsOffset+12])
+	if int(gpsIfd)+2 > len(tiff) {
+		return
+	}
+
+	count := int(order.Uint16(tiff[gpsIfd : gpsIfd+2]))
+	entries := int(gpsIfd) + 2
+	if entries+count*12 > len(tiff) {
+		return
+	}
+
+	for n := 0; n < count; n++ {
+		entry := tiff[entries+n*12 : entries+n*12+12]
+		tag := order.Uint16(entry[0:2])
+		fieldType := order.Uint16(entry[2:4])
+		size := tiffTypeSizes[fieldType] * order.Uint32(entry[4:8])
+
+		if policy == config.GpsCoarse {
+			if (tag == exifGpsLatitude || tag == exifGpsLongitude) && fieldType == tiffRational && size == 24 {
+				coarsenDegrees(tiff, order, order.Uint32(entry[8:12]))
+			}
+			continue
+		}
+
+		// Clear values stored outside the entry
+		if size > 4 {
+			offset := order.Uint32(entry[8:12])
+			if uint64(offset)+uint64(size) <= uint64(len(tiff)) {
+				clear(tiff[offset : offset+size])
+			}
+		}
+	}
+
+	if policy == config.GpsStrip {
+		clear(tiff[entries : entries+count*12])
+		order.PutUint16(tiff[gpsIfd:gpsIfd+2], 0)
+	}
+}
+
+// findIfdEntry returns the offset of the entry with the given tag in an IFD.
+func findIfdEntry(tiff []byte, order binary.ByteOrder, ifd uint32, tag uint16) (uint32, bool) {
+	if int(ifd)+2 > len(tiff) {
+		return 0, false
+	}
+
+	count := uint32(order.Uint16(tiff[ifd : ifd+2]))
+	for n := uint32(0); n < count; n++ {
+		offset := ifd + 2 + n*12
+		if int(offset)+12 > len(tiff) {
+			return 0, false
+		}
+		if order.Uint16(tiff[offset:offset+2]) == tag {
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+// coarsenDegrees truncates a degrees/minutes/seconds triple to whole minutes (~2 km).
+func coarsenDegrees(tiff []byte, order binary.ByteOrder, offset uint32) {
+	if int(offset)+24 > len(tiff) {
+		return
+	}
+
+	var total float64
+	for n, unit := range []float64{1, 60, 3600} {
+		part := tiff[offset+uint32(n)*8 : offset+uint32(n)*8+8]
+		num := order.Uint32(part[0:4])
+		den := order.Uint32(part[4:8])
+		if den != 0 {
+			total += float64(num) / float64(den) / unit
+		}
+	}
+
+	degrees := math.Floor(total)
+	minutes := math.Floor((total - degrees) * 60)
+
+	for n, value := range []uint32{uint32(degrees), uint32(minutes), 0} {
+		part := tiff[offset+uint32(n)*8 : offset+uint32(n)*8+8]
+		order.PutUint32(part[0:4], value)
+		order.PutUint32(part[4:8], 1)
+	}
+}
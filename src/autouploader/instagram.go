@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/LNA-DEV/HomePageCompanion/syndication"
 	"github.com/mmcdole/gofeed"
 )
 
@@ -82,13 +84,24 @@ func checkInstagramMediaStatus(creationID, accessToken string) (string, error) {
 
 func publishInstagramEntry(entry *gofeed.Item, target config.Target, connection config.Connection) {
 	// Build caption
-	caption := connection.Caption + "\n\n"
-	for _, tag := range entry.Categories {
+	caption := buildCaption(connection, entry) + "\n\n"
+	for _, tag := range inventory.Hashtags(entry.Categories, target.Platform) {
 		caption += "#" + tag + " "
 	}
 
 	// Get image and alt text
 	mediaURL := entry.Image.URL
+	source, _ := inventory.FindDatasource(connection.SourceName)
+	if isLocalImage(mediaURL, source) || !inventory.IsRemoteImage(mediaURL) {
+		log.Printf("Instagram needs a public image URL, cannot publish local image %s\n", entry.Title)
+		return
+	}
+	// Instagram fetches the original image by URL, there is no sanitized copy
+	// to publish instead
+	if connection.Gps == config.GpsStrip || connection.Gps == config.GpsCoarse {
+		log.Printf("Instagram cannot apply the GPS policy %q, not publishing %s\n", connection.Gps, entry.Title)
+		return
+	}
 	altText := extractAltText(entry.Description)
 	if altText == "" {
 		altText = "Alt not found"
@@ -132,7 +145,16 @@ func publishInstagramEntry(entry *gofeed.Item, target config.Target, connection
 	}
 
 	log.Printf("Published to Instagram: %s\n", *publishID)
-	if err := publishedEntry(entry.Title, target.Platform, nil, nil, publishID); err != nil {
+
+	// The media ID is not a public URL, the permalink is retried hourly if this fails
+	var postURL *string
+	if permalink, err := syndication.FetchInstagramPermalink(*publishID, target.AccessToken); err == nil {
+		postURL = &permalink
+	} else {
+		log.Printf("Could not fetch Instagram permalink: %v\n", err)
+	}
+
+	if err := publishedEntry(entry.Title, target, nil, postURL, publishID); err != nil {
 		log.Printf("Error recording published entry: %v\n", err)
 	}
 }
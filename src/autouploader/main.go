@@ -3,29 +3,22 @@ package autouploader
 import (
 	"errors"
 	"fmt"
-	"io"
 	"log"
 	"math"
 	"math/rand"
-	"net/http"
 	"regexp"
 	"time"
 
 	"github.com/LNA-DEV/HomePageCompanion/config"
 	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
 	"github.com/LNA-DEV/HomePageCompanion/models"
 	"github.com/mmcdole/gofeed"
 	"gorm.io/gorm"
 )
 
 func Publish(connection config.Connection) {
-	var source config.Datasource
-
-	for _, element := range config.Data.Datasources.Rss {
-		if element.Name == connection.SourceName {
-			source = element
-		}
-	}
+	source, _ := inventory.FindDatasource(connection.SourceName)
 
 	var target config.Target
 
@@ -36,7 +29,7 @@ func Publish(connection config.Connection) {
 		}
 	}
 
-	entry := getEntryToPublish(source, target)
+	entry := getEntryToPublish(source, target, connection)
 
 	switch target.Platform {
 	case "pixelfed":
@@ -55,11 +48,11 @@ func Publish(connection config.Connection) {
 
 }
 
-func GetPublishedEntry(item_name string, platform string) (*models.AutoUploadItem, error) {
+func GetPublishedEntry(item_name string, targetName string) (*models.AutoUploadItem, error) {
 	var item models.AutoUploadItem
 	if err := database.Db.
 		Where("item_name = ?", item_name).
-		Where("platform = ?", platform).
+		Where("target_name = ?", targetName).
 		First(&item).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -69,15 +62,13 @@ func GetPublishedEntry(item_name string, platform string) (*models.AutoUploadIte
 	return &item, nil
 }
 
-func getEntryToPublish(source config.Datasource, target config.Target) *gofeed.Item {
-	feedURL := source.FeedURL
-	parser := gofeed.NewParser()
-	feed, err := parser.ParseURL(feedURL)
+func getEntryToPublish(source config.Datasource, target config.Target, connection config.Connection) *gofeed.Item {
+	feed, err := loadSourceFeed(source)
 	if err != nil {
 		log.Fatalf("Error parsing feed: %v", err)
 	}
 
-	specificNames, err := getAlreadyUploadedItems(target.Platform)
+	specificNames, err := getAlreadyUploadedItems(target.Name)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -88,13 +79,15 @@ func getEntryToPublish(source config.Datasource, target config.Target) *gofeed.I
 		return nil
 	}
 
+	entryDate := entryDateFunc(filteredEntries, connection)
+
 	now := time.Now()
 	var closestEntry *gofeed.Item
 	var skipped []*gofeed.Item
 	minDiff := math.MaxFloat64
 
 	for _, entry := range filteredEntries {
-		published := entry.PublishedParsed
+		published := entryDate(entry)
 		if published == nil || published.Year() <= 1 {
 			skipped = append(skipped, entry)
 			continue
@@ -115,8 +108,9 @@ func getEntryToPublish(source config.Datasource, target config.Target) *gofeed.I
 	}
 
 	var closestEntries []*gofeed.Item
+	closestDate := entryDate(closestEntry)
 	for _, entry := range filteredEntries {
-		if entry.Published == closestEntry.Published {
+		if date := entryDate(entry); date != nil && date.Equal(*closestDate) {
 			closestEntries = append(closestEntries, entry)
 		}
 	}
@@ -131,9 +125,49 @@ func getEntryToPublish(source config.Datasource, target config.Target) *gofeed.I
 	return randomEntry
 }
 
-func getAlreadyUploadedItems(platform string) ([]string, error) {
+// entryDateFunc returns the date used to match entries to the current day.
+// With the "captured" date source the EXIF capture date is preferred.
+func entryDateFunc(entries []*gofeed.Item, connection config.Connection) func(*gofeed.Item) *time.Time {
+	published := func(entry *gofeed.Item) *time.Time {
+		return entry.PublishedParsed
+	}
+
+	if connection.DateSource != config.DateSourceCaptured {
+		return published
+	}
+
+	guids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		guids = append(guids, entry.GUID)
+	}
+
+	captured, err := inventory.GetCaptureDates(guids)
+	if err != nil {
+		log.Printf("Error loading capture dates, falling back to published dates: %v", err)
+		return published
+	}
+
+	return func(entry *gofeed.Item) *time.Time {
+		if date, ok := captured[entry.GUID]; ok {
+			return &date
+		}
+		return entry.PublishedParsed
+	}
+}
+
+// loadSourceFeed returns the current entries of a datasource.
+func loadSourceFeed(source config.Datasource) (*gofeed.Feed, error) {
+	if source.Type == config.DatasourceTypeDirectory {
+		return inventory.ScanDirectory(source.Path)
+	}
+
+	parser := gofeed.NewParser()
+	return parser.ParseURL(source.FeedURL)
+}
+
+func getAlreadyUploadedItems(targetName string) ([]string, error) {
 	var items []models.AutoUploadItem
-	if err := database.Db.Where("platform = ?", platform).Find(&items).Error; err != nil {
+	if err := database.Db.Where("target_name = ?", targetName).Find(&items).Error; err != nil {
 		return nil, err
 	}
 
@@ -144,17 +178,40 @@ func getAlreadyUploadedItems(platform string) ([]string, error) {
 	return names, nil
 }
 
-func publishedEntry(entryName string, platform string, versionId *string, postUrl *string, postId *string) error {
+func publishedEntry(entryName string, target config.Target, versionId *string, postUrl *string, postId *string) error {
 	item := models.AutoUploadItem{
-		Platform:  platform,
-		ItemName:  entryName,
-		VersionId: versionId,
-		PostUrl:   postUrl,
-		PostId:    postId,
+		Platform:   target.Platform,
+		TargetName: target.Name,
+		ItemName:   entryName,
+		VersionId:  versionId,
+		PostUrl:    postUrl,
+		PostId:     postId,
 	}
 	return database.Db.Create(&item).Error
 }
 
+// AssignPublicationTargets sets the target of publications recorded before
+// targets were stored. They are assigned to the first target of their
+// platform, which is the one they were published with.
+func AssignPublicationTargets() {
+	assigned := make(map[string]bool)
+	for _, target := range config.Data.Targets {
+		if assigned[target.Platform] {
+			continue
+		}
+		assigned[target.Platform] = true
+
+		result := database.Db.Model(&models.AutoUploadItem{}).
+			Where("platform = ? AND (target_name = '' OR target_name IS NULL)", target.Platform).
+			Update("target_name", target.Name)
+		if result.Error != nil {
+			log.Printf("Error assigning publications to target %s: %v", target.Name, result.Error)
+		} else if result.RowsAffected > 0 {
+			log.Printf("Assigned %d publications to target %s", result.RowsAffected, target.Name)
+		}
+	}
+}
+
 func filterEntries(entries []*gofeed.Item, nameList []string) []*gofeed.Item {
 	nameMap := make(map[string]bool)
 	for _, name := range nameList {
@@ -179,11 +236,8 @@ func extractAltText(html string) string {
 	return ""
 }
 
-func downloadImage(imageURL string) ([]byte, error) {
-	resp, err := http.Get(imageURL)
-	if err != nil || resp.StatusCode != 200 {
-		return nil, errors.New("failed to download image")
-	}
-	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
+// isLocalImage reports whether the image is served from disk by a directory
+// datasource. File URLs of other datasources are never read.
+func isLocalImage(imageURL string, source config.Datasource) bool {
+	return inventory.IsLocalImage(imageURL, source)
 }
@@ -13,15 +13,18 @@ import (
 	"strings"
 
 	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
 	"github.com/mmcdole/gofeed"
 )
 
-func uploadPixelfedMedia(entry *gofeed.Item, target config.Target) (string, error) {
+func uploadPixelfedMedia(entry *gofeed.Item, target config.Target, connection config.Connection) (string, error) {
 	imageURL := entry.Image.URL
-	imageData, err := downloadImage(imageURL)
+	source, _ := inventory.FindDatasource(connection.SourceName)
+	imageData, err := inventory.LoadImage(imageURL, source)
 	if err != nil {
 		return "", err
 	}
+	imageData = applyGpsPolicy(imageData, connection.Gps)
 
 	description := extractAltText(entry.Description)
 
@@ -100,12 +103,12 @@ func publishPixelfedPost(caption, mediaID string, target config.Target) (error,
 }
 
 func publishPixelfedEntry(entry *gofeed.Item, target config.Target, connection config.Connection) error {
-	caption := connection.Caption + "\n\n"
-	for _, tag := range entry.Categories {
+	caption := buildCaption(connection, entry) + "\n\n"
+	for _, tag := range inventory.Hashtags(entry.Categories, target.Platform) {
 		caption += "#" + tag + " "
 	}
 
-	mediaID, err := uploadPixelfedMedia(entry, target)
+	mediaID, err := uploadPixelfedMedia(entry, target, connection)
 	if err != nil {
 		return err
 	}
@@ -117,7 +120,7 @@ func publishPixelfedEntry(entry *gofeed.Item, target config.Target, connection c
 
 	log.Println("Pixelfed post published:", response.URL)
 
-	return publishedEntry(entry.Title, target.Platform, nil, &response.URL, &response.ID)
+	return publishedEntry(entry.Title, target, nil, &response.URL, &response.ID)
 }
 
 func multipartWriter(body *bytes.Buffer, image []byte, description string) *multipart.Writer {
@@ -8,21 +8,21 @@ import (
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
-	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	blueskyapi "github.com/LNA-DEV/HomePageCompanion/blue_sky_api"
 	"github.com/LNA-DEV/HomePageCompanion/config"
 	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
 	"github.com/LNA-DEV/HomePageCompanion/models"
 	"github.com/corona10/goimagehash"
-	"github.com/mmcdole/gofeed"
 )
 
-const hashDistanceThreshold = 10
+const hashDistanceThreshold = inventory.DuplicateThreshold
 
 type RSSImageData struct {
 	ItemName string
@@ -42,15 +42,13 @@ func RunBackfill() {
 			}
 		}
 
-		var source config.Datasource
-		for _, s := range config.Data.Datasources.Rss {
-			if s.Name == connection.SourceName {
-				source = s
-				break
-			}
+		source, ok := inventory.FindDatasource(connection.SourceName)
+		if !ok {
+			log.Printf("Skipping connection %s: datasource %s not found", connection.Name, connection.SourceName)
+			continue
 		}
 
-		log.Printf("Processing platform: %s", target.Platform)
+		log.Printf("Processing target: %s (%s)", target.Name, target.Platform)
 
 		switch target.Platform {
 		case "pixelfed":
@@ -65,12 +63,12 @@ func RunBackfill() {
 	log.Println("Backfill process completed.")
 }
 
-func getItemsNeedingBackfill(platform string) ([]models.AutoUploadItem, error) {
+func getItemsNeedingBackfill(target config.Target) ([]models.AutoUploadItem, error) {
 	var items []models.AutoUploadItem
-	query := database.Db.Where("platform = ?", platform)
+	query := database.Db.Where("target_name = ?", target.Name)
 
 	// Each platform needs different fields
-	switch platform {
+	switch target.Platform {
 	case "bluesky":
 		// Bluesky needs post_url and version_id
 		query = query.Where("post_url IS NULL OR version_id IS NULL")
@@ -89,29 +87,19 @@ func getItemsNeedingBackfill(platform string) ([]models.AutoUploadItem, error) {
 	return items, err
 }
 
-func loadRSSImageHashes(feedURL string) ([]RSSImageData, error) {
-	parser := gofeed.NewParser()
-	feed, err := parser.ParseURL(feedURL)
+// loadRSSImageHashes returns the perceptual hashes stored at ingest for the datasource's items
+func loadRSSImageHashes(source config.Datasource) ([]RSSImageData, error) {
+	hashes, err := inventory.LoadImageHashes(source)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse RSS feed: %w", err)
+		return nil, fmt.Errorf("failed to load stored image hashes: %w", err)
 	}
 
 	var imageData []RSSImageData
-	for _, item := range feed.Items {
-		if item.Image == nil || item.Image.URL == "" {
-			continue
-		}
-
-		hash, err := computeHashFromURL(item.Image.URL)
-		if err != nil {
-			log.Printf("Warning: could not compute hash for %s: %v", item.Title, err)
-			continue
-		}
-
+	for _, hash := range hashes {
 		imageData = append(imageData, RSSImageData{
-			ItemName: item.Title,
-			ImageURL: item.Image.URL,
-			Hash:     hash,
+			ItemName: hash.ItemName,
+			ImageURL: hash.ImageURL,
+			Hash:     hash.Hash,
 		})
 	}
 
@@ -176,10 +164,10 @@ func findMatchingRSSItem(platformHash *goimagehash.ImageHash, rssImages []RSSIma
 	return nil
 }
 
-func updateAutoUploadItem(itemName, platform string, postURL, versionID, postID *string) error {
+func updateAutoUploadItem(itemName, targetName string, postURL, versionID, postID *string) error {
 	return database.Db.
 		Model(&models.AutoUploadItem{}).
-		Where("item_name = ? AND platform = ?", itemName, platform).
+		Where("item_name = ? AND target_name = ?", itemName, targetName).
 		Updates(map[string]interface{}{
 			"post_url":   postURL,
 			"version_id": versionID,
@@ -198,20 +186,20 @@ type PixelfedStatus struct {
 }
 
 func backfillPixelfed(target config.Target, source config.Datasource) {
-	items, err := getItemsNeedingBackfill("pixelfed")
+	items, err := getItemsNeedingBackfill(target)
 	if err != nil {
-		log.Printf("Error getting items for pixelfed: %v", err)
+		log.Printf("Error getting items for %s: %v", target.Name, err)
 		return
 	}
 
 	if len(items) == 0 {
-		log.Println("No pixelfed items need backfill")
+		log.Printf("No %s items need backfill", target.Name)
 		return
 	}
 
-	log.Printf("Found %d pixelfed items needing backfill", len(items))
+	log.Printf("Found %d %s items needing backfill", len(items), target.Name)
 
-	rssImages, err := loadRSSImageHashes(source.FeedURL)
+	rssImages, err := loadRSSImageHashes(source)
 	if err != nil {
 		log.Printf("Error loading RSS hashes: %v", err)
 		return
@@ -267,7 +255,7 @@ func backfillPixelfed(target config.Target, source config.Datasource) {
 		match := findMatchingRSSItem(platformHash, relevantRSSImages)
 		if match != nil {
 			log.Printf("Matched Pixelfed post %s to RSS item %s", status.ID, match.ItemName)
-			err = updateAutoUploadItem(match.ItemName, "pixelfed", &status.URL, nil, &status.ID)
+			err = updateAutoUploadItem(match.ItemName, target.Name, &status.URL, nil, &status.ID)
 			if err != nil {
 				log.Printf("Error updating item: %v", err)
 			}
@@ -338,49 +326,21 @@ func fetchAllPixelfedStatuses(target config.Target, accountID string) ([]Pixelfe
 
 // Bluesky backfill
 
-type BlueskyFeedResponse struct {
-	Feed   []BlueskyFeedItem `json:"feed"`
-	Cursor string            `json:"cursor"`
-}
-
-type BlueskyFeedItem struct {
-	Post struct {
-		URI    string `json:"uri"`
-		CID    string `json:"cid"`
-		Record struct {
-			Embed struct {
-				Images []struct {
-					Image struct {
-						Ref struct {
-							Link string `json:"$link"`
-						} `json:"ref"`
-					} `json:"image"`
-				} `json:"images"`
-			} `json:"embed"`
-		} `json:"record"`
-		Embed struct {
-			Images []struct {
-				Fullsize string `json:"fullsize"`
-			} `json:"images"`
-		} `json:"embed"`
-	} `json:"post"`
-}
-
 func backfillBluesky(target config.Target, source config.Datasource) {
-	items, err := getItemsNeedingBackfill("bluesky")
+	items, err := getItemsNeedingBackfill(target)
 	if err != nil {
-		log.Printf("Error getting items for bluesky: %v", err)
+		log.Printf("Error getting items for %s: %v", target.Name, err)
 		return
 	}
 
 	if len(items) == 0 {
-		log.Println("No bluesky items need backfill")
+		log.Printf("No %s items need backfill", target.Name)
 		return
 	}
 
-	log.Printf("Found %d bluesky items needing backfill", len(items))
+	log.Printf("Found %d %s items needing backfill", len(items), target.Name)
 
-	rssImages, err := loadRSSImageHashes(source.FeedURL)
+	rssImages, err := loadRSSImageHashes(source)
 	if err != nil {
 		log.Printf("Error loading RSS hashes: %v", err)
 		return
@@ -404,15 +364,19 @@ func backfillBluesky(target config.Target, source config.Datasource) {
 		return
 	}
 
-	// Login to Bluesky
-	session, err := blueskyapi.BlueskyLogin(target.Username, target.PAT)
-	if err != nil {
-		log.Printf("Error logging into Bluesky: %v", err)
-		return
+	// The author feed is public, a session is only needed to find the DID
+	// when the account is configured by email
+	actor := target.Username
+	if strings.Contains(actor, "@") {
+		session, err := blueskyapi.BlueskyLogin(target.Username, target.PAT)
+		if err != nil {
+			log.Printf("Error logging into Bluesky: %v", err)
+			return
+		}
+		actor = session.Did
 	}
 
-	// Fetch all posts
-	posts, err := fetchAllBlueskyPosts(session)
+	posts, err := blueskyapi.GetAuthorFeed(actor)
 	if err != nil {
 		log.Printf("Error fetching Bluesky posts: %v", err)
 		return
@@ -434,14 +398,14 @@ func backfillBluesky(target config.Target, source config.Datasource) {
 
 		platformHash, err := computeHashFromURL(imageURL)
 		if err != nil {
-			log.Printf("Could not hash Bluesky image %s: %v", post.URI, err)
+			log.Printf("Could not hash Bluesky image %s: %v", post.Uri, err)
 			continue
 		}
 
 		match := findMatchingRSSItem(platformHash, relevantRSSImages)
 		if match != nil {
-			log.Printf("Matched Bluesky post %s to RSS item %s", post.URI, match.ItemName)
-			err = updateAutoUploadItem(match.ItemName, "bluesky", &post.URI, &post.CID, nil)
+			log.Printf("Matched Bluesky post %s to RSS item %s", post.Uri, match.ItemName)
+			err = updateAutoUploadItem(match.ItemName, target.Name, &post.Uri, &post.Cid, nil)
 			if err != nil {
 				log.Printf("Error updating item: %v", err)
 			}
@@ -449,53 +413,13 @@ func backfillBluesky(target config.Target, source config.Datasource) {
 	}
 }
 
-func fetchAllBlueskyPosts(session *blueskyapi.BlueskySession) ([]BlueskyFeedItem, error) {
-	var allPosts []BlueskyFeedItem
-	cursor := ""
-
-	for {
-		feedURL := fmt.Sprintf("https://bsky.social/xrpc/app.bsky.feed.getAuthorFeed?actor=%s&limit=50", url.QueryEscape(session.Did))
-		if cursor != "" {
-			feedURL += "&cursor=" + url.QueryEscape(cursor)
-		}
-
-		req, _ := http.NewRequest("GET", feedURL, nil)
-		req.Header.Set("Authorization", "Bearer "+session.AccessJwt)
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return allPosts, err
-		}
-
-		body, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-
-		var feedResp BlueskyFeedResponse
-		if err := json.Unmarshal(body, &feedResp); err != nil {
-			return allPosts, err
-		}
-
-		if len(feedResp.Feed) == 0 {
-			break
-		}
-
-		allPosts = append(allPosts, feedResp.Feed...)
-
-		if feedResp.Cursor == "" {
-			break
-		}
-		cursor = feedResp.Cursor
-	}
-
-	return allPosts, nil
-}
-
 // Instagram backfill
 
 type InstagramMediaResponse struct {
 	Data []struct {
-		ID       string `json:"id"`
-		MediaURL string `json:"media_url"`
+		ID        string `json:"id"`
+		MediaURL  string `json:"media_url"`
+		Permalink string `json:"permalink"`
 	} `json:"data"`
 	Paging struct {
 		Next string `json:"next"`
@@ -503,20 +427,20 @@ type InstagramMediaResponse struct {
 }
 
 func backfillInstagram(target config.Target, source config.Datasource) {
-	items, err := getItemsNeedingBackfill("instagram")
+	items, err := getItemsNeedingBackfill(target)
 	if err != nil {
-		log.Printf("Error getting items for instagram: %v", err)
+		log.Printf("Error getting items for %s: %v", target.Name, err)
 		return
 	}
 
 	if len(items) == 0 {
-		log.Println("No instagram items need backfill")
+		log.Printf("No %s items need backfill", target.Name)
 		return
 	}
 
-	log.Printf("Found %d instagram items needing backfill", len(items))
+	log.Printf("Found %d %s items needing backfill", len(items), target.Name)
 
-	rssImages, err := loadRSSImageHashes(source.FeedURL)
+	rssImages, err := loadRSSImageHashes(source)
 	if err != nil {
 		log.Printf("Error loading RSS hashes: %v", err)
 		return
@@ -564,7 +488,11 @@ func backfillInstagram(target config.Target, source config.Datasource) {
 		match := findMatchingRSSItem(platformHash, relevantRSSImages)
 		if match != nil {
 			log.Printf("Matched Instagram post %s to RSS item %s", m.ID, match.ItemName)
-			err = updateAutoUploadItem(match.ItemName, "instagram", nil, nil, &m.ID)
+			var permalink *string
+			if m.Permalink != "" {
+				permalink = &m.Permalink
+			}
+			err = updateAutoUploadItem(match.ItemName, target.Name, permalink, nil, &m.ID)
 			if err != nil {
 				log.Printf("Error updating item: %v", err)
 			}
@@ -573,13 +501,14 @@ func backfillInstagram(target config.Target, source config.Datasource) {
 }
 
 type InstagramMedia struct {
-	ID       string
-	MediaURL string
+	ID        string
+	MediaURL  string
+	Permalink string
 }
 
 func fetchAllInstagramMedia(target config.Target) ([]InstagramMedia, error) {
 	var allMedia []InstagramMedia
-	nextURL := fmt.Sprintf("https://graph.instagram.com/v22.0/%s/media?fields=id,media_url&access_token=%s",
+	nextURL := fmt.Sprintf("https://graph.instagram.com/v22.0/%s/media?fields=id,media_url,permalink&access_token=%s",
 		target.AccountId, url.QueryEscape(target.AccessToken))
 
 	for nextURL != "" {
@@ -597,8 +526,9 @@ func fetchAllInstagramMedia(target config.Target) ([]InstagramMedia, error) {
 
 		for _, m := range mediaResp.Data {
 			allMedia = append(allMedia, InstagramMedia{
-				ID:       m.ID,
-				MediaURL: m.MediaURL,
+				ID:        m.ID,
+				MediaURL:  m.MediaURL,
+				Permalink: m.Permalink,
 			})
 		}
 
@@ -0,0 +1,230 @@
+package blueskyapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+)
+
+// DefaultAppViewURL serves public reads without authentication
+const DefaultAppViewURL = "https://public.api.bsky.app"
+
+// StatusError is returned when the AppView answered with an error status
+type StatusError struct {
+	StatusCode int
+	Header     http.Header
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("Bluesky API returned status %d", e.StatusCode)
+}
+
+func (e *StatusError) Is(target error) bool {
+	return target == ErrRateLimited && e.StatusCode == http.StatusTooManyRequests
+}
+
+// Actor represents app.bsky.actor.defs#profileViewBasic
+type Actor struct {
+	Did         string `json:"did"`
+	Handle      string `json:"handle"`
+	DisplayName string `json:"displayName"`
+	Avatar      string `json:"avatar"`
+}
+
+// Like represents a single like entry
+type Like struct {
+	CreatedAt string `json:"createdAt"`
+	Actor     Actor  `json:"actor"`
+}
+
+// LikesResponse represents the response from app.bsky.feed.getLikes
+type LikesResponse struct {
+	Uri    string `json:"uri"`
+	Cid    string `json:"cid"`
+	Likes  []Like `json:"likes"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// PostView represents app.bsky.feed.defs#postView
+type PostView struct {
+	Uri         string `json:"uri"`
+	Cid         string `json:"cid"`
+	Author      Actor  `json:"author"`
+	LikeCount   int    `json:"likeCount"`
+	RepostCount int    `json:"repostCount"`
+	ReplyCount  int    `json:"replyCount"`
+	QuoteCount  int    `json:"quoteCount"`
+	Record      struct {
+		Text      string `json:"text"`
+		CreatedAt string `json:"createdAt"`
+	} `json:"record"`
+	Embed struct {
+		Images []struct {
+			Fullsize string `json:"fullsize"`
+		} `json:"images"`
+	} `json:"embed"`
+}
+
+// ThreadViewPost represents app.bsky.feed.defs#threadViewPost. Post is nil
+// for blocked or deleted replies.
+type ThreadViewPost struct {
+	Post    *PostView        `json:"post"`
+	Replies []ThreadViewPost `json:"replies"`
+}
+
+// FeedViewPost represents app.bsky.feed.defs#feedViewPost
+type FeedViewPost struct {
+	Post PostView `json:"post"`
+}
+
+// appViewURL returns the configured AppView without trailing slash
+func appViewURL() string {
+	if base := config.Data.Bluesky.AppViewURL; base != "" {
+		return strings.TrimSuffix(base, "/")
+	}
+	return DefaultAppViewURL
+}
+
+// publicGet calls a query method of the AppView and decodes the response into out
+func publicGet(method string, params url.Values, out interface{}) error {
+	req, err := http.NewRequest("GET", appViewURL()+"/xrpc/"+method+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Bluesky API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{StatusCode: resp.StatusCode, Header: resp.Header}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	return nil
+}
+
+// GetLikes returns all likes of a post
+func GetLikes(uri, cid string) (*LikesResponse, error) {
+	var result *LikesResponse
+	var likes []Like
+	cursor := ""
+
+	for {
+		params := url.Values{"uri": {uri}, "cid": {cid}, "limit": {"100"}}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		var page LikesResponse
+		if err := publicGet("app.bsky.feed.getLikes", params, &page); err != nil {
+			return nil, err
+		}
+
+		likes = append(likes, page.Likes...)
+		if result == nil {
+			result = &page
+		}
+
+		if page.Cursor == "" {
+			break
+		}
+		cursor = page.Cursor
+	}
+
+	result.Likes = likes
+	result.Cursor = ""
+	return result, nil
+}
+
+// GetRepostedBy returns all accounts that reposted a post
+func GetRepostedBy(uri, cid string) ([]Actor, error) {
+	var actors []Actor
+	cursor := ""
+
+	for {
+		params := url.Values{"uri": {uri}, "cid": {cid}, "limit": {"100"}}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		var page struct {
+			RepostedBy []Actor `json:"repostedBy"`
+			Cursor     string  `json:"cursor,omitempty"`
+		}
+		if err := publicGet("app.bsky.feed.getRepostedBy", params, &page); err != nil {
+			return nil, err
+		}
+
+		actors = append(actors, page.RepostedBy...)
+
+		if page.Cursor == "" {
+			break
+		}
+		cursor = page.Cursor
+	}
+
+	return actors, nil
+}
+
+// GetPostThread returns a post with its counters and replies up to depth levels deep
+func GetPostThread(uri string, depth int) (*ThreadViewPost, error) {
+	params := url.Values{"uri": {uri}, "depth": {strconv.Itoa(depth)}, "parentHeight": {"0"}}
+
+	var data struct {
+		Thread ThreadViewPost `json:"thread"`
+	}
+	if err := publicGet("app.bsky.feed.getPostThread", params, &data); err != nil {
+		return nil, err
+	}
+	if data.Thread.Post == nil {
+		return nil, errors.New("post not found")
+	}
+
+	return &data.Thread, nil
+}
+
+// GetAuthorFeed returns all posts and reposts of an account. The actor is a
+// handle or DID.
+func GetAuthorFeed(actor string) ([]FeedViewPost, error) {
+	var posts []FeedViewPost
+	cursor := ""
+
+	for {
+		params := url.Values{"actor": {actor}, "limit": {"50"}}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		var page struct {
+			Feed   []FeedViewPost `json:"feed"`
+			Cursor string         `json:"cursor"`
+		}
+		if err := publicGet("app.bsky.feed.getAuthorFeed", params, &page); err != nil {
+			return posts, err
+		}
+
+		if len(page.Feed) == 0 {
+			break
+		}
+		posts = append(posts, page.Feed...)
+
+		if page.Cursor == "" {
+			break
+		}
+		cursor = page.Cursor
+	}
+
+	return posts, nil
+}
@@ -2,18 +2,48 @@ package config
 
 type Config struct {
 	Security struct {
-		ApiKey     string `yaml:"apiKey"`
-		Domain     string `yaml:"domain"`
-		IPHashSalt string `yaml:"ipHashSalt"`
+		ApiKey                 string `yaml:"apiKey"`
+		Domain                 string `yaml:"domain"`
+		IPHashSalt             string `yaml:"ipHashSalt"`             // First salt version, later ones are generated and stored in the database
+		IPHashSaltRotationDays int    `yaml:"ipHashSaltRotationDays"` // A new salt is generated after this, 0 disables rotation
+		ProofOfWorkDifficulty  int    `yaml:"proofOfWorkDifficulty"`  // Leading zero bits required for native likes, 0 disables proof of work
+		// Reverse proxies (IPs or CIDRs) whose X-Real-IP header is used as the client IP.
+		// Defaults to loopback and private networks, where the bundled nginx runs.
+		// Only list proxies that overwrite X-Real-IP, like web/nginx.conf does.
+		TrustedProxies []string `yaml:"trustedProxies"`
 	} `yaml:"security"`
 	Datasources struct {
-		Rss []Datasource `yaml:"rss"`
+		Rss       []Datasource `yaml:"rss"`
+		Directory []Datasource `yaml:"directory"`
 	} `yaml:"datasources"`
 	Targets     []Target     `yaml:"targets"`
 	Connections []Connection `yaml:"connections"`
 	Webpush     struct {
 		Subscriber string `yaml:"subscriberMail"`
 	} `yaml:"webpush"`
+	OutputFeed struct {
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+		Link        string `yaml:"link"`
+	} `yaml:"outputFeed"`
+	WebSub struct {
+		CallbackBaseURL string `yaml:"callbackBaseUrl"` // Public URL of the companion, enables WebSub when set
+		LeaseSeconds    int    `yaml:"leaseSeconds"`
+	} `yaml:"websub"`
+	Bluesky struct {
+		AppViewURL string `yaml:"appViewUrl"` // Public AppView used for reads, default https://public.api.bsky.app
+	} `yaml:"bluesky"`
+	InteractionHistory struct {
+		RawRetentionDays   int `yaml:"rawRetentionDays"`   // Hourly snapshots are downsampled to daily ones after this, default 30
+		DailyRetentionDays int `yaml:"dailyRetentionDays"` // Daily snapshots are deleted after this, 0 keeps them forever
+	} `yaml:"interactionHistory"`
+	Privacy struct {
+		IPHashRetentionDays int `yaml:"ipHashRetentionDays"` // IP hashes of likes and comments are removed after this, 0 keeps them
+	} `yaml:"privacy"`
+	Milestones struct {
+		LikeThresholds []int `yaml:"likeThresholds"` // Total likes across platforms that notify the admins, default 1, 10 and 100
+	} `yaml:"milestones"`
+	NativeReactions []string `yaml:"nativeReactions"` // Emoji visitors can react with besides the like
 }
 
 type Connection struct {
@@ -22,12 +52,34 @@ type Connection struct {
 	TargetName string  `yaml:"targetName"`
 	Caption    string  `yaml:"caption"`
 	Cron       *string `yaml:"cron"`
+	DateSource string  `yaml:"dateSource"` // "published" (default) or "captured"
+	// "keep" (default), "strip" or "coarse". Instagram fetches the image by
+	// its public URL, so Instagram connections need "keep" to publish.
+	Gps string `yaml:"gps"`
 }
 
+const (
+	DateSourcePublished = "published"
+	DateSourceCaptured  = "captured"
+)
+
+const (
+	GpsKeep   = "keep"
+	GpsStrip  = "strip"
+	GpsCoarse = "coarse"
+)
+
+const (
+	DatasourceTypeRss       = "rss"
+	DatasourceTypeDirectory = "directory"
+)
+
 type Datasource struct {
 	Name     string `yaml:"name"`
 	FeedURL  string `yaml:"feedUrl"`
+	Path     string `yaml:"path"`
 	ItemType string `yaml:"itemType"`
+	Type     string `yaml:"-"` // Set from the list the datasource was configured in
 }
 
 type Target struct {
@@ -24,3 +24,13 @@ func MigrateModels(models []interface{}) {
 		}
 	}
 }
+
+// DropIndex removes an index that is no longer part of a model
+func DropIndex(model interface{}, name string) {
+	if !Db.Migrator().HasIndex(model, name) {
+		return
+	}
+	if err := Db.Migrator().DropIndex(model, name); err != nil {
+		log.Printf("Error dropping index %s: %v", name, err)
+	}
+}
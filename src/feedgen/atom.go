@@ -0,0 +1,94 @@
+package feedgen
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type atomFeed struct {
+	XMLName        xml.Name    `xml:"feed"`
+	XMLNS          string      `xml:"xmlns,attr"`
+	XMLNSCompanion string      `xml:"xmlns:companion,attr"`
+	ID             string      `xml:"id"`
+	Title          string      `xml:"title"`
+	Subtitle       string      `xml:"subtitle,omitempty"`
+	Updated        string      `xml:"updated"`
+	Links          []atomLink  `xml:"link"`
+	Entries        []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Title  string `xml:"title,attr,omitempty"`
+	Length int    `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+	Likes      int            `xml:"companion:likes"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+func renderAtom(feed outputFeed) atomFeed {
+	updated := feed.Updated
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	result := atomFeed{
+		XMLNS:          "http://www.w3.org/2005/Atom",
+		XMLNSCompanion: companionNamespace,
+		ID:             feed.SelfURL,
+		Title:          feed.Title,
+		Subtitle:       feed.Description,
+		Updated:        updated.Format(time.RFC3339),
+		Links:          []atomLink{{Href: feed.SelfURL, Rel: "self", Type: "application/atom+xml"}},
+	}
+	if feed.Link != "" {
+		result.Links = append(result.Links, atomLink{Href: feed.Link, Rel: "alternate", Type: "text/html"})
+	}
+
+	for _, item := range feed.Items {
+		entry := atomEntry{
+			ID:        item.GUID,
+			Title:     item.Title,
+			Updated:   item.Published.Format(time.RFC3339),
+			Published: item.Published.Format(time.RFC3339),
+			Content:   atomContent{Type: "html", Value: item.Description},
+			Likes:     item.Likes,
+		}
+		if link := itemLink(item); link != "" {
+			entry.Links = append(entry.Links, atomLink{Href: link, Rel: "alternate", Type: "text/html"})
+		}
+		if item.ImageURL != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.ImageURL, Rel: "enclosure", Type: item.ImageType, Length: item.ImageSize})
+		}
+		for _, link := range item.Syndication {
+			if link.URL != "" {
+				entry.Links = append(entry.Links, atomLink{Href: link.URL, Rel: "syndication", Title: link.Platform})
+			}
+		}
+		for _, category := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+
+	return result
+}
@@ -0,0 +1,66 @@
+package feedgen
+
+import (
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/syndication"
+)
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description,omitempty"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string             `json:"id"`
+	URL           string             `json:"url,omitempty"`
+	Title         string             `json:"title"`
+	ContentHTML   string             `json:"content_html"`
+	Image         string             `json:"image,omitempty"`
+	DatePublished string             `json:"date_published"`
+	Tags          []string           `json:"tags,omitempty"`
+	Companion     jsonFeedExtensions `json:"_companion"`
+}
+
+// jsonFeedExtensions follows the JSON Feed convention for custom fields
+type jsonFeedExtensions struct {
+	About           string             `json:"about"`
+	Syndication     []syndication.Link `json:"syndication"`
+	Likes           int                `json:"likes"`
+	LikesByPlatform map[string]int     `json:"likes_by_platform"`
+}
+
+func renderJSONFeed(feed outputFeed) jsonFeed {
+	result := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		HomePageURL: feed.Link,
+		FeedURL:     feed.SelfURL,
+		Description: feed.Description,
+		Items:       []jsonFeedItem{},
+	}
+
+	for _, item := range feed.Items {
+		result.Items = append(result.Items, jsonFeedItem{
+			ID:            item.GUID,
+			URL:           itemLink(item),
+			Title:         item.Title,
+			ContentHTML:   item.Description,
+			Image:         item.ImageURL,
+			DatePublished: item.Published.Format(time.RFC3339),
+			Tags:          item.Categories,
+			Companion: jsonFeedExtensions{
+				About:           companionNamespace,
+				Syndication:     item.Syndication,
+				Likes:           item.Likes,
+				LikesByPlatform: item.LikesByPlatform,
+			},
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,128 @@
+package feedgen
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/gin-gonic/gin"
+)
+
+// Namespace of the companion extension elements in RSS and Atom
+const companionNamespace = "https://github.com/LNA-DEV/HomePageCompanion/ns/1.0"
+
+const cacheControl = "public, max-age=300"
+
+// outputFeed is the format independent feed that gets rendered
+type outputFeed struct {
+	Title       string
+	Description string
+	Link        string
+	SelfURL     string
+	Updated     time.Time
+	Items       []inventory.PublicItem
+}
+
+// HandleRss renders the inventory as RSS 2.0
+func HandleRss(c *gin.Context) {
+	feed, ok := loadFeed(c)
+	if !ok {
+		return
+	}
+	c.Header("Cache-Control", cacheControl)
+	c.Render(http.StatusOK, xmlRender{contentType: "application/rss+xml; charset=utf-8", data: renderRss(feed)})
+}
+
+// HandleAtom renders the inventory as Atom
+func HandleAtom(c *gin.Context) {
+	feed, ok := loadFeed(c)
+	if !ok {
+		return
+	}
+	c.Header("Cache-Control", cacheControl)
+	c.Render(http.StatusOK, xmlRender{contentType: "application/atom+xml; charset=utf-8", data: renderAtom(feed)})
+}
+
+// HandleJSONFeed renders the inventory as JSON Feed 1.1
+func HandleJSONFeed(c *gin.Context) {
+	feed, ok := loadFeed(c)
+	if !ok {
+		return
+	}
+	c.Header("Cache-Control", cacheControl)
+	c.Header("Content-Type", "application/feed+json; charset=utf-8")
+	c.JSON(http.StatusOK, renderJSONFeed(feed))
+}
+
+// loadFeed collects the items for the request.
+// Query parameters: source (comma separated datasource names, all if empty), category, limit
+func loadFeed(c *gin.Context) (outputFeed, bool) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	var sources []string
+	for _, source := range strings.Split(c.Query("source"), ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			sources = append(sources, source)
+		}
+	}
+
+	items, err := inventory.ListRecentItems(sources, c.Query("category"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load items"})
+		return outputFeed{}, false
+	}
+
+	publicItems, err := inventory.ToPublicItems(items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load items"})
+		return outputFeed{}, false
+	}
+
+	feed := outputFeed{
+		Title:       config.Data.OutputFeed.Title,
+		Description: config.Data.OutputFeed.Description,
+		Link:        config.Data.OutputFeed.Link,
+		SelfURL:     requestURL(c),
+		Items:       publicItems,
+	}
+	if feed.Title == "" {
+		feed.Title = "Everything"
+	}
+	if feed.Link == "" && config.Data.Security.Domain != "" {
+		feed.Link = "https://" + config.Data.Security.Domain
+	}
+	for _, item := range publicItems {
+		if item.Published.After(feed.Updated) {
+			feed.Updated = item.Published
+		}
+	}
+
+	return feed, true
+}
+
+func requestURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + c.Request.URL.RequestURI()
+}
+
+// itemLink returns the item link, falling back to its first public copy
+func itemLink(item inventory.PublicItem) string {
+	if item.Link != "" {
+		return item.Link
+	}
+	for _, link := range item.Syndication {
+		if link.URL != "" {
+			return link.URL
+		}
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package feedgen
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+)
+
+type rss struct {
+	XMLName        xml.Name   `xml:"rss"`
+	Version        string     `xml:"version,attr"`
+	XMLNSAtom      string     `xml:"xmlns:atom,attr"`
+	XMLNSCompanion string     `xml:"xmlns:companion,attr"`
+	Channel        rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string                `xml:"title"`
+	Link        string                `xml:"link,omitempty"`
+	Description string                `xml:"description"`
+	GUID        rssGUID               `xml:"guid"`
+	PubDate     string                `xml:"pubDate"`
+	Categories  []string              `xml:"category"`
+	Enclosure   *rssEnclosure         `xml:"enclosure,omitempty"`
+	Syndication []companionSyndicated `xml:"companion:syndication"`
+	Likes       int                   `xml:"companion:likes"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// companionSyndicated is the extension element listing a copy of the item
+type companionSyndicated struct {
+	Platform string `xml:"platform,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+func renderRss(feed outputFeed) rss {
+	channel := rssChannel{
+		Title:       feed.Title,
+		Link:        feed.Link,
+		Description: feed.Description,
+		AtomLink:    atomLink{Href: feed.SelfURL, Rel: "self", Type: "application/rss+xml"},
+	}
+	if !feed.Updated.IsZero() {
+		channel.LastBuildDate = feed.Updated.Format(time.RFC1123Z)
+	}
+
+	for _, item := range feed.Items {
+		rssItem := rssItem{
+			Title:       item.Title,
+			Link:        itemLink(item),
+			Description: item.Description,
+			GUID:        rssGUID{Value: item.GUID},
+			PubDate:     item.Published.Format(time.RFC1123Z),
+			Categories:  item.Categories,
+			Likes:       item.Likes,
+		}
+		// Enclosures require type and length, images that were never fetched have neither
+		if item.ImageURL != "" && item.ImageType != "" && item.ImageSize > 0 {
+			rssItem.Enclosure = &rssEnclosure{URL: item.ImageURL, Length: item.ImageSize, Type: item.ImageType}
+		}
+		for _, link := range item.Syndication {
+			if link.URL != "" {
+				rssItem.Syndication = append(rssItem.Syndication, companionSyndicated{Platform: link.Platform, Href: link.URL})
+			}
+		}
+		channel.Items = append(channel.Items, rssItem)
+	}
+
+	return rss{
+		Version:        "2.0",
+		XMLNSAtom:      "http://www.w3.org/2005/Atom",
+		XMLNSCompanion: companionNamespace,
+		Channel:        channel,
+	}
+}
+
+// xmlRender writes XML with a custom content type
+type xmlRender struct {
+	contentType string
+	data        any
+}
+
+func (r xmlRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	if _, err := w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(r.data)
+}
+
+func (r xmlRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", r.contentType)
+}
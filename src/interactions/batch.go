@@ -0,0 +1,96 @@
+package interactions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/gin-gonic/gin"
+)
+
+const maxBatchSize = 100
+
+// Short, native likes change the counts at any time
+const batchCacheControl = "public, max-age=60"
+
+// HandleBatchInteractions returns the counts per platform of a page of items,
+// keyed by item name. Query: ?item=First&item=Second
+func HandleBatchInteractions(c *gin.Context) {
+	itemNames := uniqueItems(c.QueryArray("item"))
+	if len(itemNames) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one item parameter required"})
+		return
+	}
+	if len(itemNames) > maxBatchSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many items"})
+		return
+	}
+
+	var interactions []models.Interaction
+	if err := database.Db.Where("item_name IN ?", itemNames).Order("id").Find(&interactions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch interactions"})
+		return
+	}
+
+	var lastUpdated time.Time
+	for _, interaction := range interactions {
+		if interaction.UpdatedAt.After(lastUpdated) {
+			lastUpdated = interaction.UpdatedAt
+		}
+	}
+
+	etag := batchETag(itemNames, len(interactions), lastUpdated)
+	c.Header("Cache-Control", batchCacheControl)
+	c.Header("ETag", etag)
+	if !lastUpdated.IsZero() {
+		c.Header("Last-Modified", lastUpdated.UTC().Format(http.TimeFormat))
+	}
+
+	if matchesETag(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	result := make(map[string][]LikesResponse)
+	for _, name := range itemNames {
+		result[name] = []LikesResponse{}
+	}
+	for _, interaction := range interactions {
+		result[interaction.ItemName] = append(result[interaction.ItemName], LikesResponse{
+			Platform: interaction.Platform,
+			Likes:    interaction.LikeCount,
+			Reposts:  interaction.RepostCount,
+			Replies:  interaction.ReplyCount,
+			Quotes:   interaction.QuoteCount,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// batchETag changes whenever an interaction of the items is created, updated
+// or deleted. The item order does not matter.
+func batchETag(itemNames []string, count int, lastUpdated time.Time) string {
+	sorted := append([]string(nil), itemNames...)
+	sort.Strings(sorted)
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%d\x00%d", strings.Join(sorted, "\x00"), count, lastUpdated.UnixNano())
+	return `W/"` + hex.EncodeToString(hash.Sum(nil))[:32] + `"`
+}
+
+func matchesETag(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
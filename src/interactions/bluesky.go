@@ -1,121 +1,113 @@
 package interactions
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	blueskyapi "github.com/LNA-DEV/HomePageCompanion/blue_sky_api"
-	"github.com/LNA-DEV/HomePageCompanion/config"
 	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/LNA-DEV/HomePageCompanion/syndication"
 )
 
-func handleBlueskyLikes(item models.AutoUploadItem, targetName string) (*BlueskyLikesResponse, error) {
+// Replies nested deeper are not fetched
+const blueskyReplyDepth = 10
+
+func handleBlueskyInteractions(item models.AutoUploadItem, targetName string) (*InteractionCounts, error) {
 	if item.PostUrl == nil || item.VersionId == nil {
 		return nil, fmt.Errorf("post URL or version ID is nil")
 	}
 
-	result, err := GetBlueskyLikes(*item.PostUrl, *item.VersionId, targetName)
+	// All reads go to the public AppView, no session needed
+	likes, err := blueskyapi.GetLikes(*item.PostUrl, *item.VersionId)
+	if err != nil {
+		return nil, fmt.Errorf("fetching Bluesky likes failed: %w", blueskyReadError(err))
+	}
+
+	reposts, err := blueskyapi.GetRepostedBy(*item.PostUrl, *item.VersionId)
 	if err != nil {
-		return nil, fmt.Errorf("GetBlueskyLikes failed: %w", err)
+		return nil, fmt.Errorf("fetching Bluesky reposts failed: %w", blueskyReadError(err))
 	}
 
-	fmt.Printf("Post URI: %s\n", result.Uri)
-	fmt.Printf("CID: %s\n", result.Cid)
-	fmt.Printf("Likes count: %d\n", len(result.Likes))
+	thread, err := blueskyapi.GetPostThread(*item.PostUrl, blueskyReplyDepth)
+	if err != nil {
+		return nil, fmt.Errorf("fetching Bluesky thread failed: %w", blueskyReadError(err))
+	}
 
-	return result, nil
+	return &InteractionCounts{
+		Likes:     len(likes.Likes),
+		Reposts:   thread.Post.RepostCount,
+		Replies:   thread.Post.ReplyCount,
+		Quotes:    thread.Post.QuoteCount,
+		Reactions: blueskyReactions(likes.Likes, reposts, thread),
+	}, nil
 }
 
 // GetBlueskyLikes retrieves like details for a given AT URI and version (CID)
-func GetBlueskyLikes(uri, cid string, targetName string) (*BlueskyLikesResponse, error) {
-	var target config.Target
-
-	for _, element := range config.Data.Targets {
-		if element.Name == targetName {
-			target = element
-			break
-		}
+func GetBlueskyLikes(uri, cid string) (*blueskyapi.LikesResponse, error) {
+	likes, err := blueskyapi.GetLikes(uri, cid)
+	if err != nil {
+		return nil, blueskyReadError(err)
 	}
+	return likes, nil
+}
 
-	session, loginErr := blueskyapi.BlueskyLogin(target.Username, target.PAT)
-	if loginErr != nil {
-		// Convert blueskyapi.ErrRateLimited to local ErrRateLimited for retry logic
-		if errors.Is(loginErr, blueskyapi.ErrRateLimited) {
-			return nil, ErrRateLimited
-		}
-		return nil, loginErr
+// blueskyReadError turns a 429 of the AppView into a RateLimitError, so the
+// fetcher pauses until the limit resets
+func blueskyReadError(err error) error {
+	var statusErr *blueskyapi.StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests {
+		return rateLimitError(&http.Response{StatusCode: statusErr.StatusCode, Header: statusErr.Header})
 	}
+	return err
+}
 
-	client := &http.Client{}
-	var allLikes []BlueskyLike
-	var result *BlueskyLikesResponse
-	cursor := ""
-
-	for {
-		apiURL := fmt.Sprintf("https://bsky.social/xrpc/app.bsky.feed.getLikes?uri=%s&cid=%s&limit=100", uri, cid)
-		if cursor != "" {
-			apiURL += "&cursor=" + cursor
-		}
-
-		req, err := http.NewRequest("GET", apiURL, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
-
-		req.Header.Set("Authorization", "Bearer "+session.AccessJwt)
-		req.Header.Set("Accept", "application/json")
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to call Bluesky API: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode == http.StatusTooManyRequests {
-			return nil, ErrRateLimited
-		}
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("Bluesky API returned status %d", resp.StatusCode)
-		}
+// blueskyReactions converts likes, reposts and the reply tree to reactions
+func blueskyReactions(likes []blueskyapi.Like, reposts []blueskyapi.Actor, thread *blueskyapi.ThreadViewPost) []models.RemoteReaction {
+	reactions := []models.RemoteReaction{}
 
-		var data BlueskyLikesResponse
-		if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	for _, like := range likes {
+		reaction := blueskyActorReaction(ReactionLike, like.Actor)
+		if createdAt, err := time.Parse(time.RFC3339, like.CreatedAt); err == nil {
+			reaction.ReactedAt = &createdAt
 		}
+		reactions = append(reactions, reaction)
+	}
 
-		allLikes = append(allLikes, data.Likes...)
-
-		if result == nil {
-			result = &data
-		}
+	for _, actor := range reposts {
+		reactions = append(reactions, blueskyActorReaction(ReactionRepost, actor))
+	}
 
-		if data.Cursor == "" {
-			break
+	var collectReplies func(replies []blueskyapi.ThreadViewPost)
+	collectReplies = func(replies []blueskyapi.ThreadViewPost) {
+		for _, reply := range replies {
+			if reply.Post == nil {
+				continue // Blocked or deleted
+			}
+			reaction := blueskyActorReaction(ReactionReply, reply.Post.Author)
+			reaction.RemoteID = reply.Post.Uri
+			reaction.Content = reply.Post.Record.Text
+			reaction.URL = syndication.BlueskyWebURL(reply.Post.Uri)
+			if createdAt, err := time.Parse(time.RFC3339, reply.Post.Record.CreatedAt); err == nil {
+				reaction.ReactedAt = &createdAt
+			}
+			reactions = append(reactions, reaction)
+			collectReplies(reply.Replies)
 		}
-		cursor = data.Cursor
 	}
+	collectReplies(thread.Replies)
 
-	result.Likes = allLikes
-	return result, nil
+	return reactions
 }
 
-// BlueskyLike represents a single like entry
-type BlueskyLike struct {
-	CreatedAt string `json:"createdAt"`
-	Actor     struct {
-		Did         string `json:"did"`
-		Handle      string `json:"handle"`
-		DisplayName string `json:"displayName"`
-	} `json:"actor"`
-}
-
-// BlueskyLikesResponse represents the response from app.bsky.feed.getLikes
-type BlueskyLikesResponse struct {
-	Uri    string        `json:"uri"`
-	Cid    string        `json:"cid"`
-	Likes  []BlueskyLike `json:"likes"`
-	Cursor string        `json:"cursor,omitempty"`
+func blueskyActorReaction(reactionType string, actor blueskyapi.Actor) models.RemoteReaction {
+	return models.RemoteReaction{
+		Type:        reactionType,
+		ActorID:     actor.Did,
+		ActorHandle: actor.Handle,
+		ActorName:   actor.DisplayName,
+		ActorAvatar: actor.Avatar,
+		ActorURL:    "https://bsky.app/profile/" + actor.Handle,
+	}
 }
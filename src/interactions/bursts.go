@@ -0,0 +1,154 @@
+package interactions
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+)
+
+const (
+	BurstByIP   = "ip"   // Many likes from one IP hash
+	BurstByItem = "item" // Many likes on one item, e.g. from rotating IPs
+)
+
+// Burst is a time span with suspiciously many native likes for one key
+type Burst struct {
+	Kind      string    `json:"kind"`
+	Key       string    `json:"key"` // IP hash or item name
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Count     int       `json:"count"`
+	Items     int       `json:"items"`     // Distinct items liked
+	Addresses int       `json:"addresses"` // Distinct IP hashes
+	Tokens    int       `json:"tokens"`    // Distinct tokens
+}
+
+// FindBursts returns the spans since the given time in which an IP hash or
+// an item collected at least threshold native likes within window. Overlapping
+// spans of one key are merged. Most likes first.
+func FindBursts(since time.Time, window time.Duration, threshold int) ([]Burst, error) {
+	var likes []models.NativeLike
+	if err := database.Db.Where("created_at >= ?", since).Order("created_at").Find(&likes).Error; err != nil {
+		return nil, err
+	}
+
+	byIP := make(map[string][]models.NativeLike)
+	byItem := make(map[string][]models.NativeLike)
+	for _, like := range likes {
+		byIP[like.IPHash] = append(byIP[like.IPHash], like)
+		byItem[like.ItemName] = append(byItem[like.ItemName], like)
+	}
+
+	bursts := []Burst{}
+	for key, keyLikes := range byIP {
+		bursts = append(bursts, findKeyBursts(BurstByIP, key, keyLikes, window, threshold)...)
+	}
+	for key, keyLikes := range byItem {
+		bursts = append(bursts, findKeyBursts(BurstByItem, key, keyLikes, window, threshold)...)
+	}
+
+	sort.Slice(bursts, func(i, j int) bool {
+		if bursts[i].Count != bursts[j].Count {
+			return bursts[i].Count > bursts[j].Count
+		}
+		return bursts[i].Start.Before(bursts[j].Start)
+	})
+	return bursts, nil
+}
+
+// findKeyBursts slides a window over the likes of one key, which must be
+// ordered by time
+func findKeyBursts(kind string, key string, likes []models.NativeLike, window time.Duration, threshold int) []Burst {
+	var bursts []Burst
+	first, last := -1, -1 // Likes of the current burst
+
+	start := 0
+	for end := range likes {
+		for likes[end].CreatedAt.Sub(likes[start].CreatedAt) > window {
+			start++
+		}
+		if end-start+1 < threshold {
+			continue
+		}
+
+		// Overlapping windows extend the current burst
+		if first >= 0 && start <= last {
+			last = end
+			continue
+		}
+		if first >= 0 {
+			bursts = append(bursts, newBurst(kind, key, likes[first:last+1]))
+		}
+		first, last = start, end
+	}
+	if first >= 0 {
+		bursts = append(bursts, newBurst(kind, key, likes[first:last+1]))
+	}
+
+	return bursts
+}
+
+func newBurst(kind string, key string, likes []models.NativeLike) Burst {
+	items := make(map[string]bool)
+	addresses := make(map[string]bool)
+	tokens := make(map[string]bool)
+	for _, like := range likes {
+		items[like.ItemName] = true
+		addresses[like.IPHash] = true
+		tokens[like.Token] = true
+	}
+
+	return Burst{
+		Kind:      kind,
+		Key:       key,
+		Start:     likes[0].CreatedAt,
+		End:       likes[len(likes)-1].CreatedAt,
+		Count:     len(likes),
+		Items:     len(items),
+		Addresses: len(addresses),
+		Tokens:    len(tokens),
+	}
+}
+
+// RemoveBurst deletes the native likes of a burst and updates the counts of
+// the affected items. It returns the number of removed likes.
+func RemoveBurst(kind string, key string, start time.Time, end time.Time) (int64, error) {
+	query := database.Db.Where("created_at BETWEEN ? AND ?", start, end)
+	switch kind {
+	case BurstByIP:
+		query = query.Where("ip_hash = ?", key)
+	case BurstByItem:
+		query = query.Where("item_name = ?", key)
+	default:
+		return 0, errors.New("kind must be ip or item")
+	}
+
+	var likes []models.NativeLike
+	if err := query.Find(&likes).Error; err != nil {
+		return 0, err
+	}
+	if len(likes) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uint, 0, len(likes))
+	items := make(map[string]bool)
+	for _, like := range likes {
+		ids = append(ids, like.ID)
+		items[like.ItemName] = true
+	}
+
+	result := database.Db.Delete(&models.NativeLike{}, ids)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	for itemName := range items {
+		updateNativeInteractionCount(itemName)
+		publishUpdate(itemName)
+	}
+	return result.RowsAffected, nil
+}
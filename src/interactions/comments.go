@@ -0,0 +1,239 @@
+package interactions
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/LNA-DEV/HomePageCompanion/webpush"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	CommentPending  = "pending"
+	CommentApproved = "approved"
+	CommentSpam     = "spam"
+	CommentRejected = "rejected"
+)
+
+const (
+	maxCommentNameLength    = 80
+	maxCommentWebsiteLength = 200
+	maxCommentTextLength    = 2000
+)
+
+// Visitors can only post a few comments per window
+const (
+	commentWindow       = time.Hour
+	maxCommentsInWindow = 5
+)
+
+type CommentRequest struct {
+	Name    string `json:"name"`
+	Website string `json:"website"`
+	Text    string `json:"text"`
+	Token   string `json:"token"`
+}
+
+// CommentResponse is the public representation of an approved comment
+type CommentResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Website   string    `json:"website,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// IsCommentStatus reports whether status is a valid moderation state
+func IsCommentStatus(status string) bool {
+	switch status {
+	case CommentPending, CommentApproved, CommentSpam, CommentRejected:
+		return true
+	}
+	return false
+}
+
+// HandleGetComments returns the approved comments of an item, oldest first
+func HandleGetComments(c *gin.Context) {
+	itemName := c.Param("item_name")
+
+	var comments []models.Comment
+	if err := database.Db.Where("item_name = ? AND status = ?", itemName, CommentApproved).Order("created_at").Find(&comments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch comments"})
+		return
+	}
+
+	result := []CommentResponse{}
+	for _, comment := range comments {
+		result = append(result, CommentResponse{
+			ID:        comment.ID,
+			Name:      comment.Name,
+			Website:   comment.Website,
+			Text:      comment.Text,
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+
+	c.Header("Cache-Control", "public, max-age=60")
+	c.JSON(http.StatusOK, result)
+}
+
+// HandlePostComment stores a comment for moderation and notifies the admins.
+// Like native likes, visitors are identified by IP hash and token.
+func HandlePostComment(c *gin.Context) {
+	itemName := c.Param("item_name")
+	if !inventory.ItemExists(itemName) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return
+	}
+
+	var req CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		return
+	}
+
+	comment, err := validateComment(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token := req.Token
+	if token == "" {
+		token = generateToken()
+	}
+
+	ipHash, ipHashes := visitorIPHashes(c)
+
+	// Count by IP hash OR token, either one is enough to recognize a visitor
+	var recent int64
+	database.Db.Model(&models.Comment{}).
+		Where("(ip_hash IN ? OR token = ?) AND created_at > ?", ipHashes, token, time.Now().Add(-commentWindow)).
+		Count(&recent)
+	if recent >= maxCommentsInWindow {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many comments, try again later"})
+		return
+	}
+
+	var duplicates int64
+	database.Db.Model(&models.Comment{}).
+		Where("item_name = ? AND text = ? AND (ip_hash IN ? OR token = ?)", itemName, comment.Text, ipHashes, token).
+		Count(&duplicates)
+	if duplicates > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Comment already submitted"})
+		return
+	}
+
+	comment.ItemName = itemName
+	comment.Status = CommentPending
+	comment.IPHash = ipHash.Hash
+	comment.SaltVersion = ipHash.Version
+	comment.Token = token
+	if err := database.Db.Create(&comment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save comment"})
+		return
+	}
+
+	go webpush.NotifyAdmins(models.Notification{
+		Title: "New comment on " + itemName,
+		Body:  comment.Name + ": " + truncate(comment.Text, 120),
+	})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"id":     comment.ID,
+		"status": comment.Status,
+		"token":  token,
+	})
+}
+
+// SetCommentStatus moderates a comment. The native reply count follows the
+// approved comments.
+func SetCommentStatus(id uint, status string) (*models.Comment, error) {
+	if !IsCommentStatus(status) {
+		return nil, errors.New("invalid status")
+	}
+
+	var comment models.Comment
+	if err := database.Db.First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	if err := database.Db.Model(&comment).Update("status", status).Error; err != nil {
+		return nil, err
+	}
+
+	updateNativeInteractionCount(comment.ItemName)
+	publishUpdate(comment.ItemName)
+	return &comment, nil
+}
+
+// DeleteComment removes a comment. It returns false if it did not exist.
+func DeleteComment(id uint) (bool, error) {
+	var comment models.Comment
+	if err := database.Db.First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := database.Db.Delete(&comment).Error; err != nil {
+		return false, err
+	}
+
+	updateNativeInteractionCount(comment.ItemName)
+	publishUpdate(comment.ItemName)
+	return true, nil
+}
+
+func getApprovedCommentCount(itemName string) int {
+	var count int64
+	database.Db.Model(&models.Comment{}).Where("item_name = ? AND status = ?", itemName, CommentApproved).Count(&count)
+	return int(count)
+}
+
+// validateComment trims the fields and checks their lengths. Websites must be
+// http(s) URLs, so they can be linked safely.
+func validateComment(req CommentRequest) (models.Comment, error) {
+	comment := models.Comment{
+		Name:    strings.TrimSpace(req.Name),
+		Website: strings.TrimSpace(req.Website),
+		Text:    strings.TrimSpace(req.Text),
+	}
+
+	if comment.Name == "" || comment.Text == "" {
+		return comment, errors.New("name and text are required")
+	}
+	if utf8.RuneCountInString(comment.Name) > maxCommentNameLength {
+		return comment, errors.New("name is too long")
+	}
+	if utf8.RuneCountInString(comment.Text) > maxCommentTextLength {
+		return comment, errors.New("text is too long")
+	}
+
+	if comment.Website != "" {
+		parsed, err := url.Parse(comment.Website)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return comment, errors.New("website must be an http(s) URL")
+		}
+		if len(comment.Website) > maxCommentWebsiteLength {
+			return comment, errors.New("website is too long")
+		}
+	}
+
+	return comment, nil
+}
+
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length]) + "…"
+}
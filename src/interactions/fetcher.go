@@ -0,0 +1,243 @@
+package interactions
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/milestones"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+)
+
+const workersPerPlatform = 2
+
+// Posts younger than recentPostAge are fetched hourly, older ones daily.
+// The tolerance keeps a post due although the previous run finished late.
+const (
+	recentPostAge  = 7 * 24 * time.Hour
+	recentInterval = time.Hour
+	dailyInterval  = 24 * time.Hour
+	dueTolerance   = 10 * time.Minute
+)
+
+// fetchMu prevents overlapping runs if a run takes longer than the cron interval
+var fetchMu sync.Mutex
+
+type fetchJob struct {
+	item    models.AutoUploadItem
+	target  config.Target
+	attempt int
+}
+
+type fetchResult struct {
+	job    fetchJob
+	counts *InteractionCounts
+}
+
+// FetchAndStoreInteractions fetches interactions of all due publications.
+// Every platform has its own workers and rate limit, so a rate limited
+// platform does not hold up the others.
+func FetchAndStoreInteractions() {
+	if !fetchMu.TryLock() {
+		log.Println("Interactions fetch already running, skipping")
+		return
+	}
+	defer fetchMu.Unlock()
+
+	log.Println("Starting interactions fetch...")
+
+	jobs, err := dueJobs(time.Now())
+	if err != nil {
+		log.Printf("Error fetching auto upload items: %v", err)
+		return
+	}
+
+	results := make(chan fetchResult)
+	var platforms sync.WaitGroup
+	for platform, platformJobs := range jobs {
+		log.Printf("Fetching interactions of %d %s publications", len(platformJobs), platform)
+		platforms.Add(1)
+		go func(platform string, platformJobs []fetchJob) {
+			defer platforms.Done()
+			runPlatformQueue(platform, platformJobs, results)
+		}(platform, platformJobs)
+	}
+	go func() {
+		platforms.Wait()
+		close(results)
+	}()
+
+	// SQLite handles one writer, so results are stored from this goroutine only
+	for result := range results {
+		storeInteraction(result.job, result.counts)
+	}
+
+	log.Println("Finished interactions fetch")
+}
+
+// dueJobs returns the publications to fetch grouped by platform, newest first
+func dueJobs(now time.Time) (map[string][]fetchJob, error) {
+	var items []models.AutoUploadItem
+	if err := database.Db.Order("created_at DESC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	var interactions []models.Interaction
+	if err := database.Db.Find(&interactions).Error; err != nil {
+		return nil, err
+	}
+	lastFetched := make(map[string]time.Time)
+	for _, interaction := range interactions {
+		if interaction.LastFetchedAt != nil {
+			lastFetched[interaction.ItemName+"\x00"+interaction.Platform+"\x00"+interaction.TargetName] = *interaction.LastFetchedAt
+		}
+	}
+
+	targets := make(map[string]config.Target)
+	for _, target := range config.Data.Targets {
+		targets[target.Name] = target
+	}
+
+	jobs := make(map[string][]fetchJob)
+	for _, item := range items {
+		target, ok := targets[item.TargetName]
+		if !ok {
+			continue
+		}
+
+		interval := dailyInterval
+		if now.Sub(item.CreatedAt) < recentPostAge {
+			interval = recentInterval
+		}
+
+		last, fetched := lastFetched[item.ItemName+"\x00"+item.Platform+"\x00"+item.TargetName]
+		if fetched && now.Sub(last) < interval-dueTolerance {
+			continue
+		}
+
+		jobs[item.Platform] = append(jobs[item.Platform], fetchJob{item: item, target: target})
+	}
+
+	for _, platformJobs := range jobs {
+		sort.SliceStable(platformJobs, func(i, j int) bool {
+			return platformJobs[i].item.CreatedAt.After(platformJobs[j].item.CreatedAt)
+		})
+	}
+	return jobs, nil
+}
+
+// runPlatformQueue works through the jobs of one platform. Rate limited jobs
+// pause the platform's bucket and go back into the queue.
+func runPlatformQueue(platform string, jobs []fetchJob, results chan<- fetchResult) {
+	limiter := limiterFor(platform)
+	maxAttempts := DefaultRetryConfig().MaxRetries
+
+	// Requeued jobs were taken from the queue before, so it never overflows
+	queue := make(chan fetchJob, len(jobs))
+	var pending sync.WaitGroup
+	pending.Add(len(jobs))
+	for _, job := range jobs {
+		queue <- job
+	}
+	go func() {
+		pending.Wait()
+		close(queue)
+	}()
+
+	var workers sync.WaitGroup
+	for i := 0; i < workersPerPlatform; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for job := range queue {
+				limiter.Wait()
+				counts, err := fetchInteractions(job.item, job.target.Name)
+
+				if errors.Is(err, ErrRateLimited) && job.attempt < maxAttempts {
+					until := retryTime(err, job.attempt)
+					log.Printf("%s rate limited, pausing until %s", platform, until.Format(time.RFC3339))
+					limiter.PauseUntil(until)
+					job.attempt++
+					queue <- job
+					continue
+				}
+
+				if err != nil {
+					log.Printf("Error fetching %s interactions for %s: %v", job.item.Platform, job.item.ItemName, err)
+				} else {
+					results <- fetchResult{job: job, counts: counts}
+				}
+				pending.Done()
+			}
+		}()
+	}
+	workers.Wait()
+}
+
+func fetchInteractions(item models.AutoUploadItem, targetName string) (*InteractionCounts, error) {
+	switch item.Platform {
+	case "bluesky":
+		return handleBlueskyInteractions(item, targetName)
+	case "pixelfed":
+		return handlePixelfedInteractions(item, targetName)
+	case "instagram":
+		return handleInstagramInteractions(item, targetName)
+	}
+	return nil, errors.New("unsupported platform " + item.Platform)
+}
+
+func storeInteraction(job fetchJob, counts *InteractionCounts) {
+	itemName, platform, targetName := job.item.ItemName, job.item.Platform, job.target.Name
+	now := time.Now()
+
+	// Upsert interaction
+	var interaction models.Interaction
+	result := database.Db.Where("item_name = ? AND platform = ? AND target_name = ?", itemName, platform, targetName).First(&interaction)
+
+	changed := result.Error != nil ||
+		interaction.LikeCount != counts.Likes ||
+		interaction.RepostCount != counts.Reposts ||
+		interaction.ReplyCount != counts.Replies ||
+		interaction.QuoteCount != counts.Quotes
+
+	interaction.LikeCount = counts.Likes
+	interaction.RepostCount = counts.Reposts
+	interaction.ReplyCount = counts.Replies
+	interaction.QuoteCount = counts.Quotes
+	interaction.LastFetchedAt = &now
+
+	if result.Error != nil {
+		// Create new
+		interaction.ItemName = itemName
+		interaction.Platform = platform
+		interaction.TargetName = targetName
+		if err := database.Db.Create(&interaction).Error; err != nil {
+			log.Printf("Error creating interaction for %s on %s: %v", itemName, platform, err)
+		}
+	} else {
+		// Update existing
+		if err := database.Db.Save(&interaction).Error; err != nil {
+			log.Printf("Error updating interaction for %s on %s: %v", itemName, platform, err)
+		}
+	}
+
+	if counts.Reactions != nil {
+		storeReactions(itemName, platform, targetName, counts.Reactions)
+	}
+
+	recordSnapshot(itemName, platform, targetName, MetricLikes, counts.Likes)
+	recordSnapshot(itemName, platform, targetName, MetricReposts, counts.Reposts)
+	recordSnapshot(itemName, platform, targetName, MetricReplies, counts.Replies)
+	recordSnapshot(itemName, platform, targetName, MetricQuotes, counts.Quotes)
+
+	if changed {
+		publishUpdate(itemName)
+		milestones.CheckItem(itemName)
+	}
+
+	log.Printf("Stored interaction for %s on %s: %d likes, %d reposts, %d replies, %d quotes", itemName, platform, counts.Likes, counts.Reposts, counts.Replies, counts.Quotes)
+}
@@ -0,0 +1,198 @@
+package interactions
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+)
+
+const (
+	MetricLikes   = "likes"
+	MetricReposts = "reposts"
+	MetricReplies = "replies"
+	MetricQuotes  = "quotes"
+)
+
+const defaultRawRetentionDays = 30
+
+// SeriesPoint is a single value of a time series
+type SeriesPoint struct {
+	Time  time.Time `json:"t"`
+	Value int       `json:"v"`
+}
+
+// Series is the development of one metric over time
+type Series struct {
+	ItemName   string        `json:"itemName,omitempty"`
+	Platform   string        `json:"platform"`
+	TargetName string        `json:"targetName,omitempty"`
+	Metric     string        `json:"metric"`
+	Points     []SeriesPoint `json:"points"`
+}
+
+type seriesKey struct {
+	ItemName   string
+	Platform   string
+	TargetName string
+	Metric     string
+}
+
+// recordSnapshot appends the current value of a metric to the history
+func recordSnapshot(itemName string, platform string, targetName string, metric string, value int) {
+	snapshot := models.InteractionSnapshot{
+		ItemName:   itemName,
+		Platform:   platform,
+		TargetName: targetName,
+		Metric:     metric,
+		Value:      value,
+	}
+	if err := database.Db.Create(&snapshot).Error; err != nil {
+		log.Printf("Error recording %s snapshot for %s on %s: %v", metric, itemName, platform, err)
+	}
+}
+
+// CompactSnapshots keeps only the last snapshot per day for snapshots older
+// than the raw retention and deletes daily snapshots past their retention.
+func CompactSnapshots() {
+	rawDays := config.Data.InteractionHistory.RawRetentionDays
+	if rawDays <= 0 {
+		rawDays = defaultRawRetentionDays
+	}
+	rawCutoff := time.Now().AddDate(0, 0, -rawDays)
+
+	result := database.Db.Exec(`DELETE FROM interaction_snapshots WHERE created_at < ? AND id NOT IN (
+		SELECT MAX(id) FROM interaction_snapshots WHERE created_at < ?
+		GROUP BY item_name, platform, target_name, metric, substr(created_at, 1, 10)
+	)`, rawCutoff, rawCutoff)
+	if result.Error != nil {
+		log.Printf("Error downsampling interaction snapshots: %v", result.Error)
+	} else if result.RowsAffected > 0 {
+		log.Printf("Downsampled %d interaction snapshots", result.RowsAffected)
+	}
+
+	if dailyDays := config.Data.InteractionHistory.DailyRetentionDays; dailyDays > 0 {
+		dailyCutoff := time.Now().AddDate(0, 0, -dailyDays)
+		result := database.Db.Where("created_at < ?", dailyCutoff).Delete(&models.InteractionSnapshot{})
+		if result.Error != nil {
+			log.Printf("Error deleting old interaction snapshots: %v", result.Error)
+		} else if result.RowsAffected > 0 {
+			log.Printf("Deleted %d interaction snapshots past retention", result.RowsAffected)
+		}
+	}
+}
+
+// ItemHistory returns one series per platform and target for an item. The
+// last value within each interval is used.
+func ItemHistory(itemName string, metric string, from time.Time, to time.Time, interval time.Duration) ([]Series, error) {
+	var snapshots []models.InteractionSnapshot
+	if err := database.Db.
+		Where("item_name = ? AND metric = ? AND created_at BETWEEN ? AND ?", itemName, metric, from, to).
+		Order("created_at").
+		Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+
+	buckets := bucketSnapshots(snapshots, interval)
+
+	result := []Series{}
+	for key, points := range buckets {
+		series := Series{ItemName: key.ItemName, Platform: key.Platform, TargetName: key.TargetName, Metric: key.Metric}
+		for _, t := range sortedTimes(points) {
+			series.Points = append(series.Points, SeriesPoint{Time: t, Value: points[t]})
+		}
+		result = append(result, series)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Platform != result[j].Platform {
+			return result[i].Platform < result[j].Platform
+		}
+		return result[i].TargetName < result[j].TargetName
+	})
+	return result, nil
+}
+
+// PlatformHistory returns the total of a metric over all items per platform.
+// Items without a snapshot in an interval count with their last known value.
+func PlatformHistory(metric string, from time.Time, to time.Time, interval time.Duration) ([]Series, error) {
+	var snapshots []models.InteractionSnapshot
+	if err := database.Db.
+		Where("metric = ? AND created_at BETWEEN ? AND ?", metric, from, to).
+		Order("created_at").
+		Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+
+	buckets := bucketSnapshots(snapshots, interval)
+
+	times := make(map[time.Time]bool)
+	for _, points := range buckets {
+		for t := range points {
+			times[t] = true
+		}
+	}
+	var allTimes []time.Time
+	for t := range times {
+		allTimes = append(allTimes, t)
+	}
+	sort.Slice(allTimes, func(i, j int) bool { return allTimes[i].Before(allTimes[j]) })
+
+	totals := make(map[string]map[time.Time]int)
+	for key, points := range buckets {
+		if totals[key.Platform] == nil {
+			totals[key.Platform] = make(map[time.Time]int)
+		}
+		known := false
+		last := 0
+		for _, t := range allTimes {
+			if value, ok := points[t]; ok {
+				known = true
+				last = value
+			}
+			if known {
+				totals[key.Platform][t] += last
+			}
+		}
+	}
+
+	result := []Series{}
+	for platform, points := range totals {
+		series := Series{Platform: platform, Metric: metric}
+		for _, t := range allTimes {
+			if value, ok := points[t]; ok {
+				series.Points = append(series.Points, SeriesPoint{Time: t, Value: value})
+			}
+		}
+		result = append(result, series)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Platform < result[j].Platform })
+	return result, nil
+}
+
+// bucketSnapshots groups snapshots by series and interval, keeping the last
+// value of each interval. Snapshots must be ordered by time.
+func bucketSnapshots(snapshots []models.InteractionSnapshot, interval time.Duration) map[seriesKey]map[time.Time]int {
+	buckets := make(map[seriesKey]map[time.Time]int)
+	for _, snapshot := range snapshots {
+		key := seriesKey{snapshot.ItemName, snapshot.Platform, snapshot.TargetName, snapshot.Metric}
+		if buckets[key] == nil {
+			buckets[key] = make(map[time.Time]int)
+		}
+		buckets[key][snapshot.CreatedAt.UTC().Truncate(interval)] = snapshot.Value
+	}
+	return buckets
+}
+
+func sortedTimes(points map[time.Time]int) []time.Time {
+	times := make([]time.Time, 0, len(points))
+	for t := range points {
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/LNA-DEV/HomePageCompanion/config"
@@ -15,12 +17,7 @@ import (
 
 var instagramGraphURL = "https://graph.instagram.com/v22.0/"
 
-type InstagramLikesResponse struct {
-	MediaID   string `json:"media_id"`
-	LikeCount int    `json:"like_count"`
-}
-
-func handleInstagramLikes(item models.AutoUploadItem, targetName string) (*InstagramLikesResponse, error) {
+func handleInstagramInteractions(item models.AutoUploadItem, targetName string) (*InteractionCounts, error) {
 	if item.PostId == nil || *item.PostId == "" {
 		return nil, errors.New("missing PostID")
 	}
@@ -30,53 +27,133 @@ func handleInstagramLikes(item models.AutoUploadItem, targetName string) (*Insta
 		return nil, errors.New("empty Instagram access token")
 	}
 
-	likeCount, err := getInstagramLikeCount(*item.PostId, token)
+	counts, err := getInstagramCounts(*item.PostId, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Instagram interactions: %w", err)
+	}
+
+	// The Graph API does not expose who liked a post, only comments
+	comments, err := getInstagramComments(*item.PostId, token)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Instagram likes: %w", err)
+		log.Printf("Could not fetch Instagram comments for %s: %v", item.ItemName, err)
+	} else {
+		counts.Reactions = comments
+	}
+
+	return counts, nil
+}
+
+// getInstagramComments returns the comments of a media object as reactions
+func getInstagramComments(mediaID, accessToken string) ([]models.RemoteReaction, error) {
+	reactions := []models.RemoteReaction{}
+	nextURL := fmt.Sprintf("%s%s/comments?fields=id,text,username,timestamp&access_token=%s", instagramGraphURL, mediaID, url.QueryEscape(accessToken))
+
+	for nextURL != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, nextURL, nil)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			resp.Body.Close()
+			cancel()
+			return nil, rateLimitError(resp)
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			cancel()
+			return nil, fmt.Errorf("Instagram Graph API returned status %s: %s", resp.Status, string(body))
+		}
+
+		var page struct {
+			Data []struct {
+				ID        string `json:"id"`
+				Text      string `json:"text"`
+				Username  string `json:"username"`
+				Timestamp string `json:"timestamp"`
+			} `json:"data"`
+			Paging struct {
+				Next string `json:"next"`
+			} `json:"paging"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+
+		for _, comment := range page.Data {
+			reaction := models.RemoteReaction{
+				Type:        ReactionReply,
+				ActorID:     comment.Username,
+				ActorHandle: comment.Username,
+				ActorURL:    "https://www.instagram.com/" + comment.Username + "/",
+				RemoteID:    comment.ID,
+				Content:     comment.Text,
+			}
+			// Instagram uses +0000 instead of RFC 3339 offsets
+			if timestamp, err := time.Parse("2006-01-02T15:04:05-0700", comment.Timestamp); err == nil {
+				reaction.ReactedAt = &timestamp
+			}
+			reactions = append(reactions, reaction)
+		}
+
+		nextURL = page.Paging.Next
 	}
 
-	return &InstagramLikesResponse{
-		MediaID:   *item.PostId,
-		LikeCount: likeCount,
-	}, nil
+	return reactions, nil
 }
 
-func getInstagramLikeCount(mediaID, accessToken string) (int, error) {
-	endpoint := fmt.Sprintf("%s%s?fields=like_count&access_token=%s", instagramGraphURL, mediaID, accessToken)
+// getInstagramCounts returns likes and comments, Instagram has no reposts or quotes
+func getInstagramCounts(mediaID, accessToken string) (*InteractionCounts, error) {
+	endpoint := fmt.Sprintf("%s%s?fields=like_count,comments_count&access_token=%s", instagramGraphURL, mediaID, accessToken)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		return 0, ErrRateLimited
+		return nil, rateLimitError(resp)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("Instagram Graph API returned status %s: %s", resp.Status, string(body))
+		return nil, fmt.Errorf("Instagram Graph API returned status %s: %s", resp.Status, string(body))
 	}
 
 	var result struct {
-		LikeCount int    `json:"like_count"`
-		ID        string `json:"id"`
+		LikeCount     int    `json:"like_count"`
+		CommentsCount int    `json:"comments_count"`
+		ID            string `json:"id"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, fmt.Errorf("decode response: %w", err)
+		return nil, fmt.Errorf("decode response: %w", err)
 	}
 
-	return result.LikeCount, nil
+	return &InteractionCounts{Likes: result.LikeCount, Replies: result.CommentsCount}, nil
 }
 
 func getInstagramToken(targetName string) string {
@@ -3,10 +3,8 @@ package interactions
 import (
 	"encoding/json"
 	"errors"
-	"log"
 	"net/http"
 
-	"github.com/LNA-DEV/HomePageCompanion/config"
 	"github.com/LNA-DEV/HomePageCompanion/database"
 	"github.com/LNA-DEV/HomePageCompanion/models"
 	"github.com/gin-gonic/gin"
@@ -19,28 +17,12 @@ func HandleInteraction(c *gin.Context) {
 	itemName := c.Param("item_name")
 	targetName := c.Param("target_name")
 
-	var interactions []models.Interaction
-	var err error
-
-	if targetName == "all" {
-		err = database.Db.Where("item_name = ?", itemName).Find(&interactions).Error
-	} else {
-		err = database.Db.Where("item_name = ? AND target_name = ?", itemName, targetName).Find(&interactions).Error
-	}
-
+	likesList, err := loadLikes(itemName, targetName)
 	if err != nil {
 		c.Data(http.StatusInternalServerError, "application/text", []byte(err.Error()))
 		return
 	}
 
-	likesList := []LikesResponse{}
-	for _, interaction := range interactions {
-		likesList = append(likesList, LikesResponse{
-			Platform: interaction.Platform,
-			Likes:    interaction.LikeCount,
-		})
-	}
-
 	jsonData, jsonErr := json.Marshal(likesList)
 	if jsonErr != nil {
 		c.Data(http.StatusInternalServerError, "application/text", []byte(jsonErr.Error()))
@@ -50,113 +32,45 @@ func HandleInteraction(c *gin.Context) {
 	c.Data(http.StatusOK, "application/json", jsonData)
 }
 
-// FetchAndStoreInteractions fetches interactions from all platforms and stores them in the database
-func FetchAndStoreInteractions() {
-	log.Println("Starting interactions fetch...")
-
-	// Get all unique item names from AutoUploadItem
-	var items []models.AutoUploadItem
-	if err := database.Db.Find(&items).Error; err != nil {
-		log.Printf("Error fetching auto upload items: %v", err)
-		return
+// loadLikes returns the counts of an item per platform. The target "all"
+// includes every target.
+func loadLikes(itemName string, targetName string) ([]LikesResponse, error) {
+	var interactions []models.Interaction
+	query := database.Db.Where("item_name = ?", itemName)
+	if targetName != "all" {
+		query = query.Where("target_name = ?", targetName)
 	}
-
-	// Group items by item name to process each item once per platform
-	itemsByName := make(map[string][]models.AutoUploadItem)
-	for _, item := range items {
-		itemsByName[item.ItemName] = append(itemsByName[item.ItemName], item)
+	if err := query.Find(&interactions).Error; err != nil {
+		return nil, err
 	}
 
-	for itemName, platformItems := range itemsByName {
-		for _, item := range platformItems {
-			// Find the target for this platform
-			var target config.Target
-			for _, t := range config.Data.Targets {
-				if t.Platform == item.Platform {
-					target = t
-					break
-				}
-			}
-
-			if target.Name == "" {
-				continue
-			}
-
-			var likeCount int
-			var fetchErr error
-			retryConfig := DefaultRetryConfig()
-
-			switch item.Platform {
-			case "bluesky":
-				result, e := RetryWithBackoff(retryConfig, func() (*BlueskyLikesResponse, error) {
-					return handleBlueskyLikes(item, target.Name)
-				})
-				if e != nil {
-					fetchErr = e
-				} else {
-					likeCount = len(result.Likes)
-				}
-
-			case "pixelfed":
-				result, e := RetryWithBackoff(retryConfig, func() (*PixelfedLikesResponse, error) {
-					return handlePixelfedLikes(item, target.Name)
-				})
-				if e != nil {
-					fetchErr = e
-				} else {
-					likeCount = len(result.Accounts)
-				}
-
-			case "instagram":
-				result, e := RetryWithBackoff(retryConfig, func() (*InstagramLikesResponse, error) {
-					return handleInstagramLikes(item, target.Name)
-				})
-				if e != nil {
-					fetchErr = e
-				} else {
-					likeCount = result.LikeCount
-				}
-
-			default:
-				continue
-			}
-
-			if fetchErr != nil {
-				log.Printf("Error fetching %s likes for %s: %v", item.Platform, itemName, fetchErr)
-				continue
-			}
-
-			// Upsert interaction
-			var interaction models.Interaction
-			result := database.Db.Where("item_name = ? AND platform = ? AND target_name = ?", itemName, item.Platform, target.Name).First(&interaction)
-
-			if result.Error != nil {
-				// Create new
-				interaction = models.Interaction{
-					ItemName:   itemName,
-					Platform:   item.Platform,
-					TargetName: target.Name,
-					LikeCount:  likeCount,
-				}
-				if err := database.Db.Create(&interaction).Error; err != nil {
-					log.Printf("Error creating interaction for %s on %s: %v", itemName, item.Platform, err)
-				}
-			} else {
-				// Update existing
-				interaction.LikeCount = likeCount
-				if err := database.Db.Save(&interaction).Error; err != nil {
-					log.Printf("Error updating interaction for %s on %s: %v", itemName, item.Platform, err)
-				}
-			}
-
-			log.Printf("Stored interaction for %s on %s: %d likes", itemName, item.Platform, likeCount)
-		}
+	likesList := []LikesResponse{}
+	for _, interaction := range interactions {
+		likesList = append(likesList, LikesResponse{
+			Platform: interaction.Platform,
+			Likes:    interaction.LikeCount,
+			Reposts:  interaction.RepostCount,
+			Replies:  interaction.ReplyCount,
+			Quotes:   interaction.QuoteCount,
+		})
 	}
-
-	log.Println("Finished interactions fetch")
+	return likesList, nil
 }
 
 type LikesResponse struct {
 	Platform string `json:"platform"`
 	Likes    int    `json:"likes"`
+	Reposts  int    `json:"reposts"`
+	Replies  int    `json:"replies"`
+	Quotes   int    `json:"quotes"`
+}
+
+// InteractionCounts are the engagement metrics of a single post
+type InteractionCounts struct {
+	Likes   int
+	Reposts int // Bluesky reposts, Pixelfed boosts
+	Replies int // Replies or comments
+	Quotes  int
+	// Individual likes, reposts and replies, nil if the platform does not expose them
+	Reactions []models.RemoteReaction
 }
@@ -2,169 +2,330 @@ package interactions
 
 import (
 	"crypto/rand"
-	"crypto/sha256"
 	"encoding/hex"
+	"log"
 	"net/http"
 
 	"github.com/LNA-DEV/HomePageCompanion/config"
 	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/LNA-DEV/HomePageCompanion/milestones"
 	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/LNA-DEV/HomePageCompanion/security"
 	"github.com/gin-gonic/gin"
 )
 
+// ownLikeCondition matches the likes of a visitor by token and IP hash. Once
+// the retention removed the IP hash, the token alone identifies the visitor.
+const ownLikeCondition = "token = ? AND (ip_hash IN ? OR ip_hash = '')"
+
 const nativePlatform = "native"
 const nativeTargetName = "native"
 
+// NativeReactionLike is the reaction of the like endpoints
+const NativeReactionLike = "like"
+
 type NativeLikeRequest struct {
 	Token string `json:"token"`
+	security.Proof
+}
+
+type NativeReactionRequest struct {
+	Token    string `json:"token"`
+	Reaction string `json:"reaction"`
+	security.Proof
 }
 
 type NativeLikeResponse struct {
-	Success   bool   `json:"success"`
-	Token     string `json:"token,omitempty"`
-	LikeCount int    `json:"like_count"`
-	HasLiked  bool   `json:"has_liked"`
-	Message   string `json:"message,omitempty"`
+	Success    bool           `json:"success"`
+	Token      string         `json:"token,omitempty"`
+	LikeCount  int            `json:"like_count"`
+	HasLiked   bool           `json:"has_liked"`
+	Reaction   string         `json:"reaction,omitempty"`
+	Count      int            `json:"count,omitempty"`
+	HasReacted bool           `json:"has_reacted,omitempty"`
+	Reactions  map[string]int `json:"reactions,omitempty"`
+	Reacted    []string       `json:"reacted,omitempty"`
+	Message    string         `json:"message,omitempty"`
 }
 
 // HandleNativeLike handles POST requests to like an item natively
 func HandleNativeLike(c *gin.Context) {
-	itemName := c.Param("item_name")
-	ipHash := hashIP(c.ClientIP())
-
 	var req NativeLikeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		// No token provided, will generate one
 		req.Token = ""
 	}
 
+	addNativeReaction(c, req.Token, NativeReactionLike, req.Proof)
+}
+
+// HandleNativeUnlike handles DELETE requests to unlike an item
+func HandleNativeUnlike(c *gin.Context) {
+	var req NativeLikeRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		c.JSON(http.StatusBadRequest, NativeLikeResponse{
+			Success: false,
+			Message: "Token required for unlike",
+		})
+		return
+	}
+
+	removeNativeReaction(c, req.Token, NativeReactionLike)
+}
+
+// HandleNativeReact handles POST requests to react to an item with one of the
+// configured reactions
+func HandleNativeReact(c *gin.Context) {
+	var req NativeReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, NativeLikeResponse{Success: false, Message: "Invalid JSON"})
+		return
+	}
+	if !isAllowedReaction(req.Reaction) {
+		c.JSON(http.StatusBadRequest, NativeLikeResponse{Success: false, Message: "Unknown reaction"})
+		return
+	}
+
+	addNativeReaction(c, req.Token, req.Reaction, req.Proof)
+}
+
+// HandleNativeUnreact handles DELETE requests to take back a reaction
+func HandleNativeUnreact(c *gin.Context) {
+	var req NativeReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		c.JSON(http.StatusBadRequest, NativeLikeResponse{
+			Success: false,
+			Message: "Token required for unreact",
+		})
+		return
+	}
+	if !isAllowedReaction(req.Reaction) {
+		c.JSON(http.StatusBadRequest, NativeLikeResponse{Success: false, Message: "Unknown reaction"})
+		return
+	}
+
+	removeNativeReaction(c, req.Token, req.Reaction)
+}
+
+func addNativeReaction(c *gin.Context, token string, reaction string, proof security.Proof) {
+	itemName := c.Param("item_name")
+	if !inventory.ItemExists(itemName) {
+		c.JSON(http.StatusNotFound, NativeLikeResponse{Success: false, Message: "Item not found"})
+		return
+	}
+
+	// Solving a challenge makes inflating likes with scripts expensive
+	if err := security.VerifyProof(proof); err != nil {
+		c.JSON(http.StatusForbidden, NativeLikeResponse{
+			Success: false,
+			Message: "Proof of work required: " + err.Error(),
+		})
+		return
+	}
+
 	// Generate token if not provided
-	token := req.Token
 	if token == "" {
 		token = generateToken()
 	}
 
-	// Check if IP hash has already liked this item
+	ipHash, ipHashes := visitorIPHashes(c)
+
+	// Check if IP hash has already reacted this way to this item
 	var ipLike models.NativeLike
-	ipExists := database.Db.Where("item_name = ? AND ip_hash = ?", itemName, ipHash).First(&ipLike).Error == nil
+	ipExists := database.Db.Where("item_name = ? AND reaction = ? AND ip_hash IN ?", itemName, reaction, ipHashes).First(&ipLike).Error == nil
 
-	// Check if token has already liked this item
+	// Check if token has already reacted this way to this item
 	var tokenLike models.NativeLike
-	tokenExists := database.Db.Where("item_name = ? AND token = ?", itemName, token).First(&tokenLike).Error == nil
+	tokenExists := database.Db.Where("item_name = ? AND reaction = ? AND token = ?", itemName, reaction, token).First(&tokenLike).Error == nil
 
-	// Block if IP OR token has already liked (AND logic for allowing)
+	// Block if IP OR token has already reacted (AND logic for allowing)
 	if ipExists || tokenExists {
-		likeCount := getNativeLikeCount(itemName)
-		c.JSON(http.StatusConflict, NativeLikeResponse{
-			Success:   false,
-			Token:     token,
-			LikeCount: likeCount,
-			HasLiked:  true,
-			Message:   "Already liked",
-		})
+		message := "Already reacted"
+		if reaction == NativeReactionLike {
+			message = "Already liked"
+		}
+		c.JSON(http.StatusConflict, nativeResponse(itemName, reaction, false, token, true, message))
 		return
 	}
 
-	// Create new like with hashed IP
+	// Create new reaction with hashed IP
 	nativeLike := models.NativeLike{
-		ItemName: itemName,
-		IPHash:   ipHash,
-		Token:    token,
+		ItemName:    itemName,
+		Reaction:    reaction,
+		IPHash:      ipHash.Hash,
+		SaltVersion: ipHash.Version,
+		Token:       token,
 	}
 
 	if err := database.Db.Create(&nativeLike).Error; err != nil {
+		message := "Failed to save reaction"
+		if reaction == NativeReactionLike {
+			message = "Failed to save like"
+		}
 		c.JSON(http.StatusInternalServerError, NativeLikeResponse{
 			Success: false,
-			Message: "Failed to save like",
+			Message: message,
 		})
 		return
 	}
 
 	// Update the interaction count
 	updateNativeInteractionCount(itemName)
+	publishUpdate(itemName)
+	milestones.CheckItem(itemName)
 
-	likeCount := getNativeLikeCount(itemName)
-	c.JSON(http.StatusOK, NativeLikeResponse{
-		Success:   true,
-		Token:     token,
-		LikeCount: likeCount,
-		HasLiked:  true,
-	})
+	c.JSON(http.StatusOK, nativeResponse(itemName, reaction, true, token, true, ""))
 }
 
-// HandleNativeUnlike handles DELETE requests to unlike an item
-func HandleNativeUnlike(c *gin.Context) {
+func removeNativeReaction(c *gin.Context, token string, reaction string) {
 	itemName := c.Param("item_name")
-	ipHash := hashIP(c.ClientIP())
-
-	var req NativeLikeRequest
-	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
-		c.JSON(http.StatusBadRequest, NativeLikeResponse{
-			Success: false,
-			Message: "Token required for unlike",
-		})
-		return
-	}
+	_, ipHashes := visitorIPHashes(c)
 
-	// Find and delete the like that matches BOTH IP hash and token
-	result := database.Db.Where("item_name = ? AND ip_hash = ? AND token = ?", itemName, ipHash, req.Token).Delete(&models.NativeLike{})
+	// Find and delete the reaction that matches BOTH IP hash and token
+	result := database.Db.Where("item_name = ? AND reaction = ?", itemName, reaction).
+		Where(ownLikeCondition, token, ipHashes).
+		Delete(&models.NativeLike{})
 
 	if result.RowsAffected == 0 {
-		likeCount := getNativeLikeCount(itemName)
-		c.JSON(http.StatusNotFound, NativeLikeResponse{
-			Success:   false,
-			Token:     req.Token,
-			LikeCount: likeCount,
-			HasLiked:  false,
-			Message:   "Like not found",
-		})
+		message := "Reaction not found"
+		if reaction == NativeReactionLike {
+			message = "Like not found"
+		}
+		c.JSON(http.StatusNotFound, nativeResponse(itemName, reaction, false, token, false, message))
 		return
 	}
 
 	// Update the interaction count
 	updateNativeInteractionCount(itemName)
+	publishUpdate(itemName)
 
-	likeCount := getNativeLikeCount(itemName)
-	c.JSON(http.StatusOK, NativeLikeResponse{
-		Success:   true,
-		Token:     req.Token,
-		LikeCount: likeCount,
-		HasLiked:  false,
-	})
+	c.JSON(http.StatusOK, nativeResponse(itemName, reaction, true, token, false, ""))
+}
+
+// nativeResponse reports the like count and, for other reactions, the count
+// of the reaction that was changed
+func nativeResponse(itemName string, reaction string, success bool, token string, hasReacted bool, message string) NativeLikeResponse {
+	counts := getNativeReactionCounts(itemName)
+	response := NativeLikeResponse{
+		Success:   success,
+		Token:     token,
+		LikeCount: counts[NativeReactionLike],
+		Message:   message,
+	}
+	if reaction == NativeReactionLike {
+		response.HasLiked = hasReacted
+	} else {
+		response.Reaction = reaction
+		response.Count = counts[reaction]
+		response.HasReacted = hasReacted
+	}
+	return response
 }
 
-// HandleNativeLikeStatus handles GET requests to check like status
+// HandleNativeLikeStatus handles GET requests to check like status. The
+// counts of all reactions and the ones given with the token are included.
 func HandleNativeLikeStatus(c *gin.Context) {
 	itemName := c.Param("item_name")
-	ipHash := hashIP(c.ClientIP())
 	token := c.Query("token")
 
 	hasLiked := false
+	reacted := []string{}
 
 	if token != "" {
-		// Check if this IP hash + token combo has liked
-		var like models.NativeLike
-		if database.Db.Where("item_name = ? AND ip_hash = ? AND token = ?", itemName, ipHash, token).First(&like).Error == nil {
-			hasLiked = true
+		// Check which reactions this IP hash + token combo has given
+		ipHashes := security.Hashes(security.HashIPVersions(c.ClientIP()))
+		var likes []models.NativeLike
+		database.Db.Where("item_name = ?", itemName).Where(ownLikeCondition, token, ipHashes).Find(&likes)
+		for _, like := range likes {
+			if like.Reaction == NativeReactionLike {
+				hasLiked = true
+			}
+			reacted = append(reacted, like.Reaction)
 		}
 	}
 
-	likeCount := getNativeLikeCount(itemName)
+	counts := getNativeReactionCounts(itemName)
+	reactions := make(map[string]int)
+	for _, reaction := range AllowedReactions() {
+		reactions[reaction] = counts[reaction]
+	}
+
 	c.JSON(http.StatusOK, NativeLikeResponse{
 		Success:   true,
-		LikeCount: likeCount,
+		LikeCount: counts[NativeReactionLike],
 		HasLiked:  hasLiked,
+		Reactions: reactions,
+		Reacted:   reacted,
 	})
 }
 
+// AllowedReactions returns the native reactions, "like" and the configured ones
+func AllowedReactions() []string {
+	reactions := []string{NativeReactionLike}
+	for _, reaction := range config.Data.NativeReactions {
+		if reaction != NativeReactionLike {
+			reactions = append(reactions, reaction)
+		}
+	}
+	return reactions
+}
+
+func isAllowedReaction(reaction string) bool {
+	for _, allowed := range AllowedReactions() {
+		if reaction == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// getNativeReactionCounts counts the native reactions of an item by reaction.
+// Reactions removed from the config keep their counts.
+func getNativeReactionCounts(itemName string) map[string]int {
+	var rows []struct {
+		Reaction string
+		Count    int
+	}
+	database.Db.Model(&models.NativeLike{}).
+		Select("reaction, COUNT(*) AS count").
+		Where("item_name = ?", itemName).
+		Group("reaction").
+		Scan(&rows)
+
+	counts := make(map[string]int)
+	for _, row := range rows {
+		counts[row.Reaction] = row.Count
+	}
+	return counts
+}
+
+// getNativeLikeCount counts the native likes of an item. Emoji reactions are
+// not likes, they are only reported per reaction.
 func getNativeLikeCount(itemName string) int {
 	var count int64
-	database.Db.Model(&models.NativeLike{}).Where("item_name = ?", itemName).Count(&count)
+	database.Db.Model(&models.NativeLike{}).Where("item_name = ? AND reaction = ?", itemName, NativeReactionLike).Count(&count)
 	return int(count)
 }
 
+// RecountNativeInteractions recalculates the stored native counts, e.g. after
+// the way they are counted changed
+func RecountNativeInteractions() {
+	var itemNames []string
+	if err := database.Db.Model(&models.Interaction{}).Where("platform = ?", nativePlatform).Pluck("item_name", &itemNames).Error; err != nil {
+		log.Printf("Error loading native interactions: %v", err)
+		return
+	}
+	for _, itemName := range itemNames {
+		updateNativeInteractionCount(itemName)
+	}
+}
+
+// updateNativeInteractionCount stores the native likes and the approved
+// comments as replies
 func updateNativeInteractionCount(itemName string) {
 	likeCount := getNativeLikeCount(itemName)
+	replyCount := getApprovedCommentCount(itemName)
 
 	var interaction models.Interaction
 	result := database.Db.Where("item_name = ? AND platform = ? AND target_name = ?", itemName, nativePlatform, nativeTargetName).First(&interaction)
@@ -176,11 +337,13 @@ func updateNativeInteractionCount(itemName string) {
 			Platform:   nativePlatform,
 			TargetName: nativeTargetName,
 			LikeCount:  likeCount,
+			ReplyCount: replyCount,
 		}
 		database.Db.Create(&interaction)
 	} else {
 		// Update existing
 		interaction.LikeCount = likeCount
+		interaction.ReplyCount = replyCount
 		database.Db.Save(&interaction)
 	}
 }
@@ -190,8 +353,3 @@ func generateToken() string {
 	rand.Read(bytes)
 	return hex.EncodeToString(bytes)
 }
-
-func hashIP(ip string) string {
-	hash := sha256.Sum256([]byte(ip + config.Data.Security.IPHashSalt))
-	return hex.EncodeToString(hash[:])
-}
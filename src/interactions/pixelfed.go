@@ -23,13 +23,16 @@ type PixelfedAccount struct {
 	URL         string `json:"url"`
 }
 
-type PixelfedLikesResponse struct {
-	Instance string            `json:"instance"`
-	PostID   string            `json:"post_id"`
-	Accounts []PixelfedAccount `json:"accounts"`
+// PixelfedStatus is a reply from the status context
+type PixelfedStatus struct {
+	ID        string          `json:"id"`
+	URL       string          `json:"url"`
+	Content   string          `json:"content"`
+	CreatedAt string          `json:"created_at"`
+	Account   PixelfedAccount `json:"account"`
 }
 
-func handlePixelfedLikes(item models.AutoUploadItem, targetName string) (*PixelfedLikesResponse, error) {
+func handlePixelfedInteractions(item models.AutoUploadItem, targetName string) (*InteractionCounts, error) {
 	if item.PostUrl == nil || item.PostId == nil || *item.PostUrl == "" || *item.PostId == "" {
 		return nil, errors.New("missing PostURL or PostID")
 	}
@@ -45,60 +48,120 @@ func handlePixelfedLikes(item models.AutoUploadItem, targetName string) (*Pixelf
 		return nil, errors.New("empty Pixelfed token")
 	}
 
-	var allAccounts []PixelfedAccount
-	baseEndpoint := fmt.Sprintf("https://%s/api/v1/statuses/%s/favourited_by", instance, url.PathEscape(*item.PostId))
-	nextURL := baseEndpoint
+	statusURL := fmt.Sprintf("https://%s/api/v1/statuses/%s", instance, url.PathEscape(*item.PostId))
 
-	for nextURL != "" {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	likes, err := fetchPixelfedAccounts(statusURL+"/favourited_by", token)
+	if err != nil {
+		return nil, err
+	}
 
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, nextURL, nil)
-		if err != nil {
-			cancel()
-			return nil, err
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Set("Accept", "application/json")
+	reblogs, err := fetchPixelfedAccounts(statusURL+"/reblogged_by", token)
+	if err != nil {
+		return nil, err
+	}
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			cancel()
-			return nil, err
-		}
+	var statusContext struct {
+		Descendants []PixelfedStatus `json:"descendants"`
+	}
+	if err := pixelfedGet(statusURL+"/context", token, &statusContext); err != nil {
+		return nil, err
+	}
 
-		if resp.StatusCode == http.StatusTooManyRequests {
-			resp.Body.Close()
-			cancel()
-			return nil, ErrRateLimited
+	reactions := []models.RemoteReaction{}
+	for _, account := range likes {
+		reactions = append(reactions, pixelfedReaction(ReactionLike, account))
+	}
+	for _, account := range reblogs {
+		reactions = append(reactions, pixelfedReaction(ReactionRepost, account))
+	}
+	for _, reply := range statusContext.Descendants {
+		reaction := pixelfedReaction(ReactionReply, reply.Account)
+		reaction.RemoteID = reply.ID
+		reaction.Content = plainText(reply.Content)
+		reaction.URL = reply.URL
+		if createdAt, err := time.Parse(time.RFC3339, reply.CreatedAt); err == nil {
+			reaction.ReactedAt = &createdAt
 		}
+		reactions = append(reactions, reaction)
+	}
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			resp.Body.Close()
-			cancel()
-			return nil, fmt.Errorf("pixelfed API %s -> %s", nextURL, resp.Status)
-		}
+	// Pixelfed has no quotes
+	return &InteractionCounts{
+		Likes:     len(likes),
+		Reposts:   len(reblogs),
+		Replies:   len(statusContext.Descendants),
+		Reactions: reactions,
+	}, nil
+}
+
+func pixelfedReaction(reactionType string, account PixelfedAccount) models.RemoteReaction {
+	return models.RemoteReaction{
+		Type:        reactionType,
+		ActorID:     account.ID,
+		ActorHandle: account.Acct,
+		ActorName:   account.DisplayName,
+		ActorAvatar: account.Avatar,
+		ActorURL:    account.URL,
+	}
+}
 
+// fetchPixelfedAccounts follows the Link header pagination of an account list endpoint
+func fetchPixelfedAccounts(endpoint string, token string) ([]PixelfedAccount, error) {
+	var allAccounts []PixelfedAccount
+	nextURL := endpoint
+
+	for nextURL != "" {
 		var accounts []PixelfedAccount
-		if err := json.NewDecoder(resp.Body).Decode(&accounts); err != nil {
-			resp.Body.Close()
-			cancel()
-			return nil, fmt.Errorf("decode response: %w", err)
+		linkHeader, err := pixelfedRequest(nextURL, token, &accounts)
+		if err != nil {
+			return nil, err
 		}
 
 		allAccounts = append(allAccounts, accounts...)
 
 		// Parse Link header for pagination
-		nextURL = parseNextLink(resp.Header.Get("Link"))
+		nextURL = parseNextLink(linkHeader)
+	}
+
+	return allAccounts, nil
+}
+
+func pixelfedGet(endpoint string, token string, target interface{}) error {
+	_, err := pixelfedRequest(endpoint, token, target)
+	return err
+}
 
-		resp.Body.Close()
-		cancel()
+// pixelfedRequest decodes a GET response into target and returns its Link header
+func pixelfedRequest(endpoint string, token string, target interface{}) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
 	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
 
-	return &PixelfedLikesResponse{
-		Instance: instance,
-		PostID:   *item.PostId,
-		Accounts: allAccounts,
-	}, nil
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return "", rateLimitError(resp)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("pixelfed API %s -> %s", endpoint, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return "", fmt.Errorf("decode response: %w", err)
+	}
+
+	return resp.Header.Get("Link"), nil
 }
 
 func extractInstance(postURL string) (string, error) {
@@ -0,0 +1,242 @@
+package interactions
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/LNA-DEV/HomePageCompanion/security"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PrivacyRequest identifies a visitor by the token of their likes and
+// comments and optionally their push subscription endpoint
+type PrivacyRequest struct {
+	Token    string `json:"token"`
+	Endpoint string `json:"endpoint"`
+}
+
+// VisitorData is everything stored about a visitor
+type VisitorData struct {
+	Likes        []VisitorLike        `json:"likes"`
+	Comments     []VisitorComment     `json:"comments"`
+	Subscription *VisitorSubscription `json:"subscription"`
+}
+
+type VisitorLike struct {
+	ItemName  string    `json:"itemName"`
+	Reaction  string    `json:"reaction"`
+	IPHash    string    `json:"ipHash,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type VisitorComment struct {
+	ItemName  string    `json:"itemName"`
+	Name      string    `json:"name"`
+	Website   string    `json:"website,omitempty"`
+	Text      string    `json:"text"`
+	Status    string    `json:"status"`
+	IPHash    string    `json:"ipHash,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type VisitorSubscription struct {
+	Endpoint  string    `json:"endpoint"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ErasureResult counts the deleted records
+type ErasureResult struct {
+	Likes         int64 `json:"likes"`
+	Comments      int64 `json:"comments"`
+	Subscriptions int64 `json:"subscriptions"`
+}
+
+// HandlePrivacyExport returns all data stored for a token and push endpoint.
+// POST, so the token does not end up in access logs.
+func HandlePrivacyExport(c *gin.Context) {
+	var req PrivacyRequest
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Token == "" && req.Endpoint == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Token or endpoint required"})
+		return
+	}
+
+	data := VisitorData{Likes: []VisitorLike{}, Comments: []VisitorComment{}}
+
+	if req.Token != "" {
+		var likes []models.NativeLike
+		if err := database.Db.Where("token = ?", req.Token).Order("created_at").Find(&likes).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export data"})
+			return
+		}
+		for _, like := range likes {
+			data.Likes = append(data.Likes, VisitorLike{
+				ItemName:  like.ItemName,
+				Reaction:  like.Reaction,
+				IPHash:    like.IPHash,
+				CreatedAt: like.CreatedAt,
+			})
+		}
+
+		var comments []models.Comment
+		if err := database.Db.Where("token = ?", req.Token).Order("created_at").Find(&comments).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export data"})
+			return
+		}
+		for _, comment := range comments {
+			data.Comments = append(data.Comments, VisitorComment{
+				ItemName:  comment.ItemName,
+				Name:      comment.Name,
+				Website:   comment.Website,
+				Text:      comment.Text,
+				Status:    comment.Status,
+				IPHash:    comment.IPHash,
+				CreatedAt: comment.CreatedAt,
+			})
+		}
+	}
+
+	if req.Endpoint != "" {
+		var subscription models.NotificationSubscription
+		database.Db.Where("endpoint = ?", req.Endpoint).Limit(1).Find(&subscription)
+		if subscription.ID != 0 {
+			data.Subscription = &VisitorSubscription{Endpoint: subscription.Endpoint, CreatedAt: subscription.CreatedAt}
+		}
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, data)
+}
+
+// HandlePrivacyErase deletes all data stored for a token and push endpoint
+func HandlePrivacyErase(c *gin.Context) {
+	var req PrivacyRequest
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Token == "" && req.Endpoint == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Token or endpoint required"})
+		return
+	}
+
+	var result ErasureResult
+	if req.Token != "" {
+		var err error
+		result, err = eraseNative("token", []string{req.Token})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to erase data"})
+			return
+		}
+	}
+
+	if req.Endpoint != "" {
+		deleted := database.Db.Unscoped().Where("endpoint = ?", req.Endpoint).Delete(&models.NotificationSubscription{})
+		if deleted.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to erase data"})
+			return
+		}
+		result.Subscriptions = deleted.RowsAffected
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// EraseIPHash deletes the native likes and comments of IP hashes
+func EraseIPHash(ipHashes ...string) (ErasureResult, error) {
+	return eraseNative("ip_hash", ipHashes)
+}
+
+// eraseNative deletes the likes and comments whose column has one of the
+// values and updates the counts of the affected items
+func eraseNative(column string, values []string) (ErasureResult, error) {
+	condition := column + " IN ?"
+	var result ErasureResult
+	items := make(map[string]bool)
+
+	err := database.Db.Transaction(func(tx *gorm.DB) error {
+		var likes []models.NativeLike
+		if err := tx.Where(condition, values).Find(&likes).Error; err != nil {
+			return err
+		}
+		var comments []models.Comment
+		if err := tx.Where(condition, values).Find(&comments).Error; err != nil {
+			return err
+		}
+
+		for _, like := range likes {
+			items[like.ItemName] = true
+		}
+		for _, comment := range comments {
+			items[comment.ItemName] = true
+		}
+
+		deleted := tx.Where(condition, values).Delete(&models.NativeLike{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Likes = deleted.RowsAffected
+
+		deleted = tx.Where(condition, values).Delete(&models.Comment{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Comments = deleted.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for itemName := range items {
+		updateNativeInteractionCount(itemName)
+		publishUpdate(itemName)
+	}
+	return result, nil
+}
+
+// ApplyPrivacyRetention removes IP hashes older than the configured retention
+// or made with a salt that was rotated out from likes and comments. The likes
+// are kept, so counts do not change.
+func ApplyPrivacyRetention() {
+	if version := security.OldestSaltVersion(); version > 0 {
+		clearIPHashes("salt_version < ?", version)
+	}
+
+	days := config.Data.Privacy.IPHashRetentionDays
+	if days > 0 {
+		clearIPHashes("created_at < ?", time.Now().AddDate(0, 0, -days))
+	}
+}
+
+func clearIPHashes(condition string, value interface{}) {
+	for _, model := range []interface{}{&models.NativeLike{}, &models.Comment{}} {
+		result := database.Db.Model(model).Where(condition+" AND ip_hash != ''", value).Update("ip_hash", "")
+		if result.Error != nil {
+			log.Printf("Error removing old IP hashes: %v", result.Error)
+		} else if result.RowsAffected > 0 {
+			log.Printf("Removed %d old IP hashes", result.RowsAffected)
+		}
+	}
+}
+
+// visitorIPHashes hashes the client IP with the current and previous salt.
+// Rows still hashed with the previous salt are moved to the current one, so
+// they stay matchable after the next rotation.
+func visitorIPHashes(c *gin.Context) (security.IPHash, []string) {
+	hashes := security.HashIPVersions(c.ClientIP())
+	current := hashes[0]
+
+	if len(hashes) > 1 {
+		previous := security.Hashes(hashes[1:])
+		for _, model := range []interface{}{&models.NativeLike{}, &models.Comment{}} {
+			err := database.Db.Model(model).Where("ip_hash IN ?", previous).
+				Updates(map[string]interface{}{"ip_hash": current.Hash, "salt_version": current.Version}).Error
+			if err != nil {
+				log.Printf("Error rehashing IP hashes: %v", err)
+			}
+		}
+	}
+
+	return current, security.Hashes(hashes)
+}
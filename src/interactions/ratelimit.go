@@ -0,0 +1,162 @@
+package interactions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitError is returned when a platform answered 429. Until is zero if
+// the response did not say when to retry.
+type RateLimitError struct {
+	Until time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Until.IsZero() {
+		return "rate limited"
+	}
+	return fmt.Sprintf("rate limited until %s", e.Until.Format(time.RFC3339))
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// rateLimitError reads Retry-After and the ratelimit-reset headers used by
+// Bluesky (unix seconds) and Mastodon compatible servers (ISO 8601).
+func rateLimitError(resp *http.Response) error {
+	now := time.Now()
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return &RateLimitError{Until: now.Add(time.Duration(seconds) * time.Second)}
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return &RateLimitError{Until: date}
+		}
+	}
+
+	for _, header := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		value := resp.Header.Get(header)
+		if value == "" {
+			continue
+		}
+		if date, err := time.Parse(time.RFC3339, value); err == nil {
+			return &RateLimitError{Until: date}
+		}
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			// Small values are seconds from now, large ones a unix timestamp
+			if seconds < 1_000_000_000 {
+				return &RateLimitError{Until: now.Add(time.Duration(seconds) * time.Second)}
+			}
+			return &RateLimitError{Until: time.Unix(seconds, 0)}
+		}
+	}
+
+	return &RateLimitError{}
+}
+
+// retryTime returns when a rate limited request may be retried
+func retryTime(err error, attempt int) time.Time {
+	var rateLimit *RateLimitError
+	if errors.As(err, &rateLimit) && !rateLimit.Until.IsZero() {
+		return rateLimit.Until
+	}
+	return time.Now().Add(backoffDelay(DefaultRetryConfig(), attempt))
+}
+
+// tokenBucket limits the request rate to one platform. A rate limit response
+// pauses the whole bucket, so workers of other platforms keep going.
+type tokenBucket struct {
+	mu          sync.Mutex
+	tokens      float64
+	capacity    float64
+	rate        float64 // Tokens per second
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newTokenBucket(rate float64, capacity float64) *tokenBucket {
+	return &tokenBucket{tokens: capacity, capacity: capacity, rate: rate, last: time.Now()}
+}
+
+// Wait blocks until a token is available
+func (b *tokenBucket) Wait() {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+
+		if now.Before(b.pausedUntil) {
+			wait := b.pausedUntil.Sub(now)
+			b.mu.Unlock()
+			time.Sleep(wait)
+			continue
+		}
+
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return
+		}
+
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+		time.Sleep(wait)
+	}
+}
+
+// PauseUntil stops handing out tokens until the given time
+func (b *tokenBucket) PauseUntil(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+	b.tokens = 0
+	b.last = until
+}
+
+// Fetches per second and burst per platform. A fetch makes several requests:
+// Bluesky allows 3000 requests per 5 minutes, Mastodon compatible servers 300
+// and Instagram 200 per hour.
+var platformLimits = map[string]struct {
+	Rate  float64
+	Burst float64
+}{
+	"bluesky":   {Rate: 2, Burst: 5},
+	"pixelfed":  {Rate: 0.25, Burst: 3},
+	"instagram": {Rate: 1.0 / 40, Burst: 5},
+}
+
+var (
+	limitersMu sync.Mutex
+	limiters   = make(map[string]*tokenBucket)
+)
+
+// limiterFor returns the shared bucket of a platform, it outlives a single fetch run
+func limiterFor(platform string) *tokenBucket {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+
+	if limiter, ok := limiters[platform]; ok {
+		return limiter
+	}
+
+	limit, ok := platformLimits[platform]
+	if !ok {
+		limit.Rate, limit.Burst = 1, 1
+	}
+	limiters[platform] = newTokenBucket(limit.Rate, limit.Burst)
+	return limiters[platform]
+}
@@ -0,0 +1,143 @@
+package interactions
+
+import (
+	"html"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ReactionLike   = "like"
+	ReactionRepost = "repost"
+	ReactionReply  = "reply"
+)
+
+var (
+	lineBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</p>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// Actor is the public profile of someone who reacted
+type Actor struct {
+	Handle string `json:"handle"`
+	Name   string `json:"name,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// ReactionResponse is a single remote reaction
+type ReactionResponse struct {
+	Platform  string  `json:"platform"`
+	Actor     Actor   `json:"actor"`
+	Content   string  `json:"content,omitempty"`
+	URL       string  `json:"url,omitempty"`
+	ReactedAt *string `json:"reactedAt,omitempty"`
+}
+
+// ReactionsResponse groups the reactions to an item by type
+type ReactionsResponse struct {
+	Likes   []ReactionResponse `json:"likes"`
+	Reposts []ReactionResponse `json:"reposts"`
+	Replies []ReactionResponse `json:"replies"`
+}
+
+// HandleReactions returns who liked, reposted and replied to an item on other platforms
+func HandleReactions(c *gin.Context) {
+	itemName := c.Param("item_name")
+
+	var reactions []models.RemoteReaction
+	if err := database.Db.Where("item_name = ?", itemName).Order("reacted_at").Order("id").Find(&reactions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch reactions"})
+		return
+	}
+
+	response := ReactionsResponse{
+		Likes:   []ReactionResponse{},
+		Reposts: []ReactionResponse{},
+		Replies: []ReactionResponse{},
+	}
+	for _, reaction := range reactions {
+		entry := ReactionResponse{
+			Platform: reaction.Platform,
+			Actor: Actor{
+				Handle: reaction.ActorHandle,
+				Name:   reaction.ActorName,
+				Avatar: reaction.ActorAvatar,
+				URL:    reaction.ActorURL,
+			},
+			Content: reaction.Content,
+			URL:     reaction.URL,
+		}
+		if reaction.ReactedAt != nil {
+			reactedAt := reaction.ReactedAt.UTC().Format("2006-01-02T15:04:05Z")
+			entry.ReactedAt = &reactedAt
+		}
+
+		switch reaction.Type {
+		case ReactionLike:
+			response.Likes = append(response.Likes, entry)
+		case ReactionRepost:
+			response.Reposts = append(response.Reposts, entry)
+		case ReactionReply:
+			response.Replies = append(response.Replies, entry)
+		}
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, response)
+}
+
+// storeReactions replaces the stored reactions of a publication with the
+// fetched ones, so removed likes and deleted replies disappear.
+func storeReactions(itemName string, platform string, targetName string, reactions []models.RemoteReaction) {
+	err := database.Db.Transaction(func(tx *gorm.DB) error {
+		var keep []uint
+		for _, reaction := range reactions {
+			reaction.ItemName = itemName
+			reaction.Platform = platform
+			reaction.TargetName = targetName
+
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "item_name"}, {Name: "platform"}, {Name: "target_name"}, {Name: "type"}, {Name: "actor_id"}, {Name: "remote_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"actor_handle", "actor_name", "actor_avatar", "actor_url", "content", "url", "reacted_at", "updated_at"}),
+			}).Create(&reaction).Error
+			if err != nil {
+				return err
+			}
+
+			// The ID is not set by an upsert that updated an existing row
+			var stored models.RemoteReaction
+			if err := tx.Select("id").Where(
+				"item_name = ? AND platform = ? AND target_name = ? AND type = ? AND actor_id = ? AND remote_id = ?",
+				itemName, platform, targetName, reaction.Type, reaction.ActorID, reaction.RemoteID,
+			).First(&stored).Error; err != nil {
+				return err
+			}
+			keep = append(keep, stored.ID)
+		}
+
+		query := tx.Where("item_name = ? AND platform = ? AND target_name = ?", itemName, platform, targetName)
+		if len(keep) > 0 {
+			query = query.Where("id NOT IN ?", keep)
+		}
+		return query.Delete(&models.RemoteReaction{}).Error
+	})
+	if err != nil {
+		log.Printf("Error storing reactions for %s on %s: %v", itemName, targetName, err)
+	}
+}
+
+// plainText converts reply HTML to text, keeping line breaks
+func plainText(content string) string {
+	content = lineBreakPattern.ReplaceAllString(content, "\n")
+	content = htmlTagPattern.ReplaceAllString(content, "")
+	return strings.TrimSpace(html.UnescapeString(content))
+}
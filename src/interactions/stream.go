@@ -0,0 +1,155 @@
+package interactions
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Proxies close idle connections, the heartbeat keeps streams open
+const streamHeartbeatInterval = 25 * time.Second
+
+// A single page should not need more
+const maxStreamItems = 100
+
+// Updates are dropped for subscribers that fall this far behind
+const subscriberBuffer = 16
+
+// InteractionUpdate carries the current counts of an item
+type InteractionUpdate struct {
+	ItemName     string          `json:"itemName"`
+	Interactions []LikesResponse `json:"interactions"`
+}
+
+type subscriber chan InteractionUpdate
+
+// hub fans out interaction updates to the streams subscribed to an item
+type hub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[subscriber]bool
+}
+
+var updates = &hub{subscribers: make(map[string]map[subscriber]bool)}
+
+func (h *hub) subscribe(itemNames []string) subscriber {
+	sub := make(subscriber, subscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, itemName := range itemNames {
+		if h.subscribers[itemName] == nil {
+			h.subscribers[itemName] = make(map[subscriber]bool)
+		}
+		h.subscribers[itemName][sub] = true
+	}
+	return sub
+}
+
+func (h *hub) unsubscribe(sub subscriber, itemNames []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, itemName := range itemNames {
+		delete(h.subscribers[itemName], sub)
+		if len(h.subscribers[itemName]) == 0 {
+			delete(h.subscribers, itemName)
+		}
+	}
+}
+
+func (h *hub) hasSubscribers(itemName string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers[itemName]) > 0
+}
+
+// publish never blocks, slow subscribers miss updates and catch up with the next one
+func (h *hub) publish(update InteractionUpdate) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.subscribers[update.ItemName] {
+		select {
+		case sub <- update:
+		default:
+		}
+	}
+}
+
+// publishUpdate sends the current counts of an item to its open streams
+func publishUpdate(itemName string) {
+	if !updates.hasSubscribers(itemName) {
+		return
+	}
+
+	likes, err := loadLikes(itemName, "all")
+	if err != nil {
+		log.Printf("Error loading interactions of %s for stream: %v", itemName, err)
+		return
+	}
+	updates.publish(InteractionUpdate{ItemName: itemName, Interactions: likes})
+}
+
+// HandleStream streams the counts of the requested items as server-sent
+// events. The current counts are sent first, then every change.
+// Query parameters: item (repeatable)
+func HandleStream(c *gin.Context) {
+	itemNames := uniqueItems(c.QueryArray("item"))
+	if len(itemNames) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter item required"})
+		return
+	}
+	if len(itemNames) > maxStreamItems {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many items"})
+		return
+	}
+
+	// Subscribe before loading the current counts, so no change is missed
+	sub := updates.subscribe(itemNames)
+	defer updates.unsubscribe(sub, itemNames)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	for _, itemName := range itemNames {
+		likes, err := loadLikes(itemName, "all")
+		if err != nil {
+			log.Printf("Error loading interactions of %s for stream: %v", itemName, err)
+			continue
+		}
+		c.SSEvent("interactions", InteractionUpdate{ItemName: itemName, Interactions: likes})
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case update := <-sub:
+			c.SSEvent("interactions", update)
+		case <-heartbeat.C:
+			// Comment lines are ignored by EventSource
+			io.WriteString(w, ": heartbeat\n\n")
+		}
+		return true
+	})
+}
+
+func uniqueItems(itemNames []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, itemName := range itemNames {
+		if itemName != "" && !seen[itemName] {
+			seen[itemName] = true
+			unique = append(unique, itemName)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,289 @@
+package inventory
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NormalizeCategory lowercases a category name and collapses whitespace, so
+// "Landscape" and " landscape" are the same category.
+func NormalizeCategory(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// CanonicalCategoryName returns the normalized name a category is stored
+// under, following aliases.
+func CanonicalCategoryName(name string) string {
+	name = NormalizeCategory(name)
+
+	// Find instead of First, a missing alias is the common case and not worth logging
+	var category models.Category
+	database.Db.
+		Joins("JOIN category_aliases ON category_aliases.category_id = categories.id AND category_aliases.deleted_at IS NULL").
+		Where("category_aliases.alias = ?", name).
+		Limit(1).
+		Find(&category)
+	if category.ID != 0 {
+		return category.Name
+	}
+	return name
+}
+
+// resolveCategories finds or creates the categories for raw feed category
+// names. Names resolving to the same category are only returned once.
+func resolveCategories(names []string) []models.Category {
+	var categories []models.Category
+	seen := make(map[uint]bool)
+
+	for _, name := range names {
+		name = CanonicalCategoryName(name)
+		if name == "" {
+			continue
+		}
+
+		var category models.Category
+		if err := database.Db.FirstOrCreate(&category, models.Category{Name: name}).Error; err != nil {
+			log.Printf("Error saving category '%s': %v", name, err)
+			continue
+		}
+		if !seen[category.ID] {
+			seen[category.ID] = true
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// NormalizeCategories merges categories created before names were normalized.
+func NormalizeCategories() {
+	var categories []models.Category
+	if err := database.Db.Find(&categories).Error; err != nil {
+		log.Printf("Error loading categories: %v", err)
+		return
+	}
+
+	for _, category := range categories {
+		normalized := NormalizeCategory(category.Name)
+		if normalized == category.Name {
+			continue
+		}
+
+		var target models.Category
+		err := database.Db.Where("name = ?", normalized).First(&target).Error
+		if err == gorm.ErrRecordNotFound {
+			itemIDs := categoryItemIDs(database.Db, category.ID)
+			if err := database.Db.Model(&category).Update("name", normalized).Error; err != nil {
+				log.Printf("Error normalizing category '%s': %v", category.Name, err)
+				continue
+			}
+			updateSearchIndex(itemIDs)
+			continue
+		}
+		if err != nil {
+			log.Printf("Error normalizing category '%s': %v", category.Name, err)
+			continue
+		}
+
+		if err := MergeCategories(category.ID, target.ID); err != nil {
+			log.Printf("Error merging category '%s' into '%s': %v", category.Name, target.Name, err)
+		}
+	}
+}
+
+// ListCategories returns all categories with their aliases and hashtag mappings.
+func ListCategories() ([]models.Category, error) {
+	var categories []models.Category
+	if err := database.Db.Preload("Aliases").Preload("Hashtags").Order("name").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// MergeCategories moves all items of one category to another and keeps the
+// old name as alias, so future feed items are sorted into the target too.
+func MergeCategories(fromID uint, intoID uint) error {
+	if fromID == intoID {
+		return errors.New("cannot merge a category into itself")
+	}
+
+	var itemIDs []uint
+	err := database.Db.Transaction(func(tx *gorm.DB) error {
+		var from, into models.Category
+		if err := tx.First(&from, fromID).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&into, intoID).Error; err != nil {
+			return err
+		}
+		itemIDs = categoryItemIDs(tx, from.ID)
+
+		if err := tx.Exec(
+			"INSERT OR IGNORE INTO feed_item_categories (feed_item_id, category_id) SELECT feed_item_id, ? FROM feed_item_categories WHERE category_id = ?",
+			into.ID, from.ID,
+		).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM feed_item_categories WHERE category_id = ?", from.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.CategoryAlias{}).Where("category_id = ?", from.ID).Update("category_id", into.ID).Error; err != nil {
+			return err
+		}
+		if alias := NormalizeCategory(from.Name); alias != into.Name {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.CategoryAlias{Alias: alias, CategoryID: into.ID}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Keep hashtag mappings for platforms the target has none for
+		var hashtags []models.CategoryHashtag
+		tx.Where("category_id = ?", from.ID).Find(&hashtags)
+		for _, hashtag := range hashtags {
+			var count int64
+			tx.Model(&models.CategoryHashtag{}).Where("category_id = ? AND platform = ?", into.ID, hashtag.Platform).Count(&count)
+			if count == 0 {
+				tx.Create(&models.CategoryHashtag{CategoryID: into.ID, Platform: hashtag.Platform, Hashtag: hashtag.Hashtag})
+			}
+		}
+		if err := tx.Unscoped().Where("category_id = ?", from.ID).Delete(&models.CategoryHashtag{}).Error; err != nil {
+			return err
+		}
+
+		if from.Blocked && !into.Blocked {
+			tx.Model(&into).Update("blocked", true)
+		}
+
+		// Hard delete, the unique name would block the name from coming back
+		return tx.Unscoped().Delete(&from).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// The index holds the category names of the moved items
+	updateSearchIndex(itemIDs)
+	return nil
+}
+
+// categoryItemIDs returns the IDs of the items in a category.
+func categoryItemIDs(db *gorm.DB, categoryID uint) []uint {
+	var itemIDs []uint
+	if err := db.Table("feed_item_categories").Where("category_id = ?", categoryID).Pluck("feed_item_id", &itemIDs).Error; err != nil {
+		log.Printf("Error loading items of category %d: %v", categoryID, err)
+	}
+	return itemIDs
+}
+
+// AddCategoryAlias makes alias resolve to the category. An existing category
+// with that name is merged.
+func AddCategoryAlias(categoryID uint, alias string) error {
+	alias = NormalizeCategory(alias)
+	if alias == "" {
+		return errors.New("alias must not be empty")
+	}
+
+	var category models.Category
+	if err := database.Db.First(&category, categoryID).Error; err != nil {
+		return err
+	}
+	if category.Name == alias {
+		return errors.New("alias equals the category name")
+	}
+
+	var existing models.Category
+	if err := database.Db.Where("name = ?", alias).First(&existing).Error; err == nil {
+		return MergeCategories(existing.ID, category.ID)
+	}
+
+	var aliasEntry models.CategoryAlias
+	database.Db.Unscoped().Where("alias = ?", alias).FirstOrInit(&aliasEntry)
+	aliasEntry.Alias = alias
+	aliasEntry.CategoryID = category.ID
+	aliasEntry.DeletedAt = gorm.DeletedAt{}
+	return database.Db.Unscoped().Save(&aliasEntry).Error
+}
+
+// DeleteCategoryAlias removes an alias of a category. It returns false if it did not exist.
+func DeleteCategoryAlias(categoryID uint, aliasID uint) (bool, error) {
+	result := database.Db.Unscoped().Where("category_id = ?", categoryID).Delete(&models.CategoryAlias{}, aliasID)
+	return result.RowsAffected > 0, result.Error
+}
+
+// UpdateCategory sets the blocked flag and hashtag mappings of a category.
+// Hashtags are keyed by platform, an empty hashtag removes the mapping.
+func UpdateCategory(id uint, blocked *bool, hashtags map[string]string) error {
+	return database.Db.Transaction(func(tx *gorm.DB) error {
+		var category models.Category
+		if err := tx.First(&category, id).Error; err != nil {
+			return err
+		}
+
+		if blocked != nil {
+			if err := tx.Model(&category).Update("blocked", *blocked).Error; err != nil {
+				return err
+			}
+		}
+
+		for platform, hashtag := range hashtags {
+			if err := tx.Unscoped().Where("category_id = ? AND platform = ?", category.ID, platform).Delete(&models.CategoryHashtag{}).Error; err != nil {
+				return err
+			}
+			hashtag = sanitizeHashtag(hashtag)
+			if hashtag == "" {
+				continue
+			}
+			if err := tx.Create(&models.CategoryHashtag{CategoryID: category.ID, Platform: platform, Hashtag: hashtag}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Hashtags returns the hashtags for raw feed categories on a platform, without
+// the leading "#". Aliases and platform mappings are applied and blocked
+// categories are left out.
+func Hashtags(names []string, platform string) []string {
+	var hashtags []string
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		name = CanonicalCategoryName(name)
+
+		var category models.Category
+		err := database.Db.Preload("Hashtags", "platform = ?", platform).Where("name = ?", name).First(&category).Error
+		if err == nil && category.Blocked {
+			continue
+		}
+
+		hashtag := name
+		if err == nil && len(category.Hashtags) > 0 {
+			hashtag = category.Hashtags[0].Hashtag
+		}
+
+		hashtag = sanitizeHashtag(hashtag)
+		if hashtag != "" && !seen[hashtag] {
+			seen[hashtag] = true
+			hashtags = append(hashtags, hashtag)
+		}
+	}
+	return hashtags
+}
+
+// sanitizeHashtag drops everything platforms do not allow in a hashtag
+func sanitizeHashtag(tag string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, tag)
+}
@@ -0,0 +1,83 @@
+package inventory
+
+import (
+	"log"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+)
+
+// Datasources returns all configured and database managed datasources with
+// their Type set. Config entries win if a name exists in both.
+func Datasources() []config.Datasource {
+	var sources []config.Datasource
+	names := make(map[string]bool)
+
+	for _, source := range config.Data.Datasources.Rss {
+		source.Type = config.DatasourceTypeRss
+		sources = append(sources, source)
+		names[source.Name] = true
+	}
+
+	for _, source := range config.Data.Datasources.Directory {
+		source.Type = config.DatasourceTypeDirectory
+		sources = append(sources, source)
+		names[source.Name] = true
+	}
+
+	stored, err := ListStoredDatasources()
+	if err != nil {
+		log.Printf("Error loading stored datasources: %v", err)
+		return sources
+	}
+
+	for _, source := range stored {
+		if names[source.Name] {
+			continue
+		}
+		sources = append(sources, config.Datasource{
+			Name:     source.Name,
+			FeedURL:  source.FeedURL,
+			Path:     source.Path,
+			ItemType: source.ItemType,
+			Type:     source.Type,
+		})
+	}
+
+	return sources
+}
+
+// FindDatasource returns the datasource with the given name.
+func FindDatasource(name string) (config.Datasource, bool) {
+	for _, source := range Datasources() {
+		if source.Name == name {
+			return source, true
+		}
+	}
+	return config.Datasource{}, false
+}
+
+// IsConfigDatasource reports whether the datasource is defined in the config file.
+func IsConfigDatasource(name string) bool {
+	for _, source := range config.Data.Datasources.Rss {
+		if source.Name == name {
+			return true
+		}
+	}
+	for _, source := range config.Data.Datasources.Directory {
+		if source.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ListStoredDatasources returns the datasources managed in the database.
+func ListStoredDatasources() ([]models.Datasource, error) {
+	var sources []models.Datasource
+	if err := database.Db.Order("name").Find(&sources).Error; err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
@@ -0,0 +1,299 @@
+package inventory
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/LNA-DEV/HomePageCompanion/syndication"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const publicCacheControl = "public, max-age=300"
+
+const likeTotalsQuery = "LEFT JOIN (SELECT item_name, SUM(like_count) AS total_likes FROM interactions GROUP BY item_name) likes ON likes.item_name = feed_items.title"
+
+// PublicItem is the public representation of a feed item
+type PublicItem struct {
+	ID              uint               `json:"id"`
+	GUID            string             `json:"guid"`
+	Title           string             `json:"title"`
+	Description     string             `json:"description"`
+	Link            string             `json:"link"`
+	ImageURL        string             `json:"imageUrl"`
+	ImageWidth      int                `json:"imageWidth,omitempty"`
+	ImageHeight     int                `json:"imageHeight,omitempty"`
+	ImageType       string             `json:"imageType,omitempty"`
+	ImageSize       int                `json:"imageSize,omitempty"`
+	Feed            string             `json:"feed"`
+	Categories      []string           `json:"categories"`
+	Published       time.Time          `json:"published"`
+	CapturedAt      *time.Time         `json:"capturedAt,omitempty"`
+	Syndication     []syndication.Link `json:"syndication"`
+	Likes           int                `json:"likes"`
+	LikesByPlatform map[string]int     `json:"likesByPlatform"`
+}
+
+// ItemsPage is a page of public items with the cursor for the next page
+type ItemsPage struct {
+	Items      []PublicItem `json:"items"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
+// itemsCursor marks the last item of a page
+type itemsCursor struct {
+	Published time.Time `json:"p,omitempty"`
+	Likes     int64     `json:"l,omitempty"`
+	ID        uint      `json:"id"`
+}
+
+// HandleListItems returns published items with cursor pagination.
+// Query parameters: cursor, limit, category, feed, from, to, sort (published|likes)
+func HandleListItems(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	sortByLikes := c.Query("sort") == "likes"
+
+	query := database.Db.Model(&models.FeedItem{}).
+		Scopes(PublicItemsScope).
+		Select("feed_items.*, COALESCE(likes.total_likes, 0) AS total_likes").
+		Joins(likeTotalsQuery)
+
+	if category := c.Query("category"); category != "" {
+		query = query.
+			Joins("JOIN feed_item_categories ON feed_item_categories.feed_item_id = feed_items.id").
+			Joins("JOIN categories ON categories.id = feed_item_categories.category_id").
+			Where("categories.name = ?", CanonicalCategoryName(category))
+	}
+
+	if feed := c.Query("feed"); feed != "" {
+		query = query.
+			Joins("JOIN feeds ON feeds.id = feed_items.feed_id").
+			Where("feeds.feed_name = ?", feed)
+	}
+
+	for param, condition := range map[string]string{"from": "feed_items.published >= ?", "to": "feed_items.published <= ?"} {
+		if value := c.Query(param); value != "" {
+			date, err := parseDateParam(value)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param + " date"})
+				return
+			}
+			query = query.Where(condition, date)
+		}
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := decodeCursor(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+		if sortByLikes {
+			query = query.Where("COALESCE(likes.total_likes, 0) < ? OR (COALESCE(likes.total_likes, 0) = ? AND feed_items.id < ?)", cursor.Likes, cursor.Likes, cursor.ID)
+		} else {
+			query = query.Where("feed_items.published < ? OR (feed_items.published = ? AND feed_items.id < ?)", cursor.Published, cursor.Published, cursor.ID)
+		}
+	}
+
+	if sortByLikes {
+		query = query.Order("total_likes DESC").Order("feed_items.id DESC")
+	} else {
+		query = query.Order("feed_items.published DESC").Order("feed_items.id DESC")
+	}
+
+	var rows []struct {
+		models.FeedItem
+		TotalLikes int64
+	}
+	if err := query.Limit(limit + 1).Find(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+
+	page := ItemsPage{Items: []PublicItem{}}
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	items := make([]models.FeedItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.FeedItem)
+	}
+
+	publicItems, err := ToPublicItems(items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+	page.Items = publicItems
+
+	if hasMore {
+		last := rows[len(rows)-1]
+		page.NextCursor = encodeCursor(itemsCursor{Published: last.Published, Likes: last.TotalLikes, ID: last.ID})
+	}
+
+	c.Header("Cache-Control", publicCacheControl)
+	c.JSON(http.StatusOK, page)
+}
+
+// HandleGetItem returns a single public item by ID
+func HandleGetItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	item, err := GetFeedItemByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+	if item == nil || strings.HasPrefix(item.GUID, "file://") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Item not found"})
+		return
+	}
+
+	publicItems, err := ToPublicItems([]models.FeedItem{*item})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item"})
+		return
+	}
+
+	c.Header("Cache-Control", publicCacheControl)
+	c.JSON(http.StatusOK, publicItems[0])
+}
+
+// PublicItemsScope excludes items of directory datasources, which are not
+// published on the homepage and would expose local paths.
+func PublicItemsScope(db *gorm.DB) *gorm.DB {
+	return db.Where("feed_items.feed_id NOT IN (SELECT id FROM feeds WHERE feed_url LIKE 'file://%')")
+}
+
+// ToPublicItems converts items to their public representation, loading
+// categories, feeds, publications and likes in batches.
+func ToPublicItems(items []models.FeedItem) ([]PublicItem, error) {
+	result := []PublicItem{}
+	if len(items) == 0 {
+		return result, nil
+	}
+
+	ids := make([]uint, 0, len(items))
+	names := make([]string, 0, len(items))
+	feedIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+		names = append(names, item.Title)
+		feedIDs = append(feedIDs, item.FeedID)
+	}
+
+	var withCategories []models.FeedItem
+	if err := database.Db.Preload("Categories").Find(&withCategories, ids).Error; err != nil {
+		return nil, err
+	}
+	categories := make(map[uint][]string)
+	for _, item := range withCategories {
+		categories[item.ID] = []string{}
+		for _, category := range item.Categories {
+			categories[item.ID] = append(categories[item.ID], category.Name)
+		}
+	}
+
+	var feeds []models.Feed
+	if err := database.Db.Find(&feeds, feedIDs).Error; err != nil {
+		return nil, err
+	}
+	feedNames := make(map[uint]string)
+	for _, feed := range feeds {
+		feedNames[feed.ID] = feed.FeedName
+	}
+
+	links, err := syndication.LoadLinks(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var interactions []models.Interaction
+	if err := database.Db.Where("item_name IN ?", names).Find(&interactions).Error; err != nil {
+		return nil, err
+	}
+	likes := make(map[string]map[string]int)
+	for _, interaction := range interactions {
+		if likes[interaction.ItemName] == nil {
+			likes[interaction.ItemName] = make(map[string]int)
+		}
+		likes[interaction.ItemName][interaction.Platform] += interaction.LikeCount
+	}
+
+	for _, item := range items {
+		public := PublicItem{
+			ID:              item.ID,
+			GUID:            item.GUID,
+			Title:           item.Title,
+			Description:     item.Description,
+			Link:            item.Link,
+			ImageURL:        item.ImageUrl,
+			ImageWidth:      item.ImageWidth,
+			ImageHeight:     item.ImageHeight,
+			ImageType:       item.ImageType,
+			ImageSize:       item.ImageSize,
+			Feed:            feedNames[item.FeedID],
+			Categories:      categories[item.ID],
+			Published:       item.Published,
+			CapturedAt:      item.Exif.CapturedAt,
+			Syndication:     links[item.Title],
+			LikesByPlatform: likes[item.Title],
+		}
+		if public.Syndication == nil {
+			public.Syndication = []syndication.Link{}
+		}
+		if public.LikesByPlatform == nil {
+			public.LikesByPlatform = map[string]int{}
+		}
+		for _, count := range public.LikesByPlatform {
+			public.Likes += count
+		}
+		result = append(result, public)
+	}
+
+	return result, nil
+}
+
+func parseDateParam(value string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func encodeCursor(cursor itemsCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw string) (itemsCursor, error) {
+	var cursor itemsCursor
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, err
+	}
+	if cursor.ID == 0 {
+		return cursor, errors.New("cursor without item ID")
+	}
+	return cursor, nil
+}
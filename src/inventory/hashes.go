@@ -0,0 +1,138 @@
+package inventory
+
+import (
+	"bytes"
+	"image"
+	"log"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/corona10/goimagehash"
+)
+
+// DuplicateThreshold is the maximum perceptual hash distance for two images to count as the same photo
+const DuplicateThreshold = 10
+
+// ItemHash is the stored perceptual hash of a feed item's image
+type ItemHash struct {
+	ItemID   uint
+	ItemName string
+	ImageURL string
+	Hash     *goimagehash.ImageHash
+}
+
+// DuplicatePair describes two items with near-identical images
+type DuplicatePair struct {
+	First    DuplicateItem `json:"first"`
+	Second   DuplicateItem `json:"second"`
+	Distance int           `json:"distance"`
+}
+
+// DuplicateItem is the item summary returned with a duplicate pair
+type DuplicateItem struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	ImageURL string `json:"imageUrl"`
+}
+
+func computeImageHash(data []byte) (string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return "", err
+	}
+	return hash.ToString(), nil
+}
+
+// SourceFeedURL returns the URL the datasource's feed is stored under.
+func SourceFeedURL(source config.Datasource) string {
+	if source.Type == config.DatasourceTypeDirectory {
+		return DirectoryFeedURL(source.Path)
+	}
+	return source.FeedURL
+}
+
+// LoadImageHashes returns the stored hashes of all items of a datasource.
+func LoadImageHashes(source config.Datasource) ([]ItemHash, error) {
+	var items []models.FeedItem
+	err := database.Db.
+		Joins("JOIN feeds ON feeds.id = feed_items.feed_id").
+		Where("feeds.feed_url = ? AND feed_items.image_hash <> ''", SourceFeedURL(source)).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return parseItemHashes(items), nil
+}
+
+// FindDuplicates returns all pairs of items whose images are within threshold of each other.
+func FindDuplicates(threshold int) ([]DuplicatePair, error) {
+	var items []models.FeedItem
+	if err := database.Db.Where("image_hash <> ''").Order("id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	hashes := parseItemHashes(items)
+	pairs := []DuplicatePair{}
+	for i := range hashes {
+		for j := i + 1; j < len(hashes); j++ {
+			distance, err := hashes[i].Hash.Distance(hashes[j].Hash)
+			if err != nil || distance > threshold {
+				continue
+			}
+			pairs = append(pairs, DuplicatePair{
+				First:    duplicateItem(hashes[i]),
+				Second:   duplicateItem(hashes[j]),
+				Distance: distance,
+			})
+		}
+	}
+	return pairs, nil
+}
+
+// warnAboutDuplicates logs other items whose image is nearly identical to the given one.
+func warnAboutDuplicates(item models.FeedItem) {
+	hash, err := goimagehash.ImageHashFromString(item.ImageHash)
+	if err != nil {
+		return
+	}
+
+	var others []models.FeedItem
+	if err := database.Db.Where("image_hash <> '' AND guid <> ?", item.GUID).Find(&others).Error; err != nil {
+		return
+	}
+
+	for _, other := range parseItemHashes(others) {
+		if distance, err := hash.Distance(other.Hash); err == nil && distance <= DuplicateThreshold {
+			log.Printf("Warning: '%s' looks like a duplicate of '%s' (distance %d)", item.Title, other.ItemName, distance)
+		}
+	}
+}
+
+func parseItemHashes(items []models.FeedItem) []ItemHash {
+	var hashes []ItemHash
+	for _, item := range items {
+		hash, err := goimagehash.ImageHashFromString(item.ImageHash)
+		if err != nil {
+			log.Printf("Invalid image hash for '%s': %v", item.Title, err)
+			continue
+		}
+		hashes = append(hashes, ItemHash{
+			ItemID:   item.ID,
+			ItemName: item.Title,
+			ImageURL: item.ImageUrl,
+			Hash:     hash,
+		})
+	}
+	return hashes
+}
+
+func duplicateItem(hash ItemHash) DuplicateItem {
+	return DuplicateItem{ID: hash.ItemID, Title: hash.ItemName, ImageURL: hash.ImageURL}
+}
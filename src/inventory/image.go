@@ -0,0 +1,199 @@
+package inventory
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/rwcarlsen/goexif/exif"
+	_ "golang.org/x/image/webp"
+)
+
+var imageClient = &http.Client{Timeout: 30 * time.Second}
+
+// LoadImage downloads an image. File URLs are only read for directory
+// datasources and only below their path, so a feed cannot make us read and
+// publish arbitrary local files.
+func LoadImage(imageURL string, source config.Datasource) ([]byte, error) {
+	if strings.HasPrefix(imageURL, "file://") {
+		path, err := localImagePath(imageURL, source)
+		if err != nil {
+			return nil, err
+		}
+		return os.ReadFile(path)
+	}
+
+	if !IsRemoteImage(imageURL) {
+		return nil, errors.New("image URL must be http(s)")
+	}
+
+	resp, err := imageClient.Get(imageURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("failed to download image")
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// IsLocalImage reports whether the image is a file of the directory datasource.
+func IsLocalImage(imageURL string, source config.Datasource) bool {
+	_, err := localImagePath(imageURL, source)
+	return err == nil
+}
+
+// IsRemoteImage reports whether the image URL can be downloaded.
+func IsRemoteImage(imageURL string) bool {
+	u, err := url.Parse(imageURL)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// localImagePath returns the path of a file URL if it lies below the path of
+// the directory datasource.
+func localImagePath(imageURL string, source config.Datasource) (string, error) {
+	if source.Type != config.DatasourceTypeDirectory || source.Path == "" {
+		return "", errors.New("local images are only read for directory datasources")
+	}
+
+	u, err := url.Parse(imageURL)
+	if err != nil || u.Scheme != "file" {
+		return "", errors.New("invalid file URL")
+	}
+
+	root, err := filepath.Abs(source.Path)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Clean(filepath.FromSlash(u.Path))
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return "", fmt.Errorf("%s is outside of datasource %s", path, source.Name)
+	}
+	return path, nil
+}
+
+// imageRetryInterval is how long an image that could not be loaded is not
+// downloaded again
+const imageRetryInterval = 24 * time.Hour
+
+// enrichImage downloads the item's image once and stores its type, size,
+// dimensions, EXIF metadata and perceptual hash. The fetch time is also set when the image
+// cannot be decoded, so it is not downloaded again on every sync. Images that
+// cannot be loaded are marked as failed and retried after imageRetryInterval.
+func enrichImage(item *models.FeedItem, source config.Datasource) {
+	if item.ImageUrl == "" {
+		return
+	}
+
+	data, err := LoadImage(item.ImageUrl, source)
+	if err != nil {
+		log.Printf("Error loading image for '%s': %v", item.Title, err)
+		now := time.Now()
+		item.ImageFailedAt = &now
+		return
+	}
+
+	item.ImageType = http.DetectContentType(data)
+	item.ImageSize = len(data)
+
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		item.ImageWidth = cfg.Width
+		item.ImageHeight = cfg.Height
+	}
+
+	item.Exif = extractExif(data)
+
+	if hash, err := computeImageHash(data); err == nil {
+		item.ImageHash = hash
+	} else {
+		log.Printf("Error hashing image for '%s': %v", item.Title, err)
+	}
+
+	now := time.Now()
+	item.ImageFetchedAt = &now
+}
+
+func extractExif(data []byte) models.ImageExif {
+	var result models.ImageExif
+
+	x, err := exif.Decode(bytes.NewReader(data))
+	if err != nil {
+		return result
+	}
+
+	result.CameraMake = exifString(x, exif.Make)
+	result.CameraModel = exifString(x, exif.Model)
+	result.LensModel = exifString(x, exif.LensModel)
+	result.FocalLength = exifFloat(x, exif.FocalLength)
+	result.FNumber = exifFloat(x, exif.FNumber)
+
+	if tag, err := x.Get(exif.ExposureTime); err == nil {
+		if num, den, err := tag.Rat2(0); err == nil && num > 0 && den > 0 {
+			if num < den {
+				result.ExposureTime = fmt.Sprintf("1/%d", den/num)
+			} else {
+				result.ExposureTime = fmt.Sprintf("%gs", float64(num)/float64(den))
+			}
+		}
+	}
+
+	if tag, err := x.Get(exif.ISOSpeedRatings); err == nil {
+		if iso, err := tag.Int(0); err == nil {
+			result.ISO = iso
+		}
+	}
+
+	if captured, err := x.DateTime(); err == nil {
+		result.CapturedAt = &captured
+	}
+
+	if lat, long, err := x.LatLong(); err == nil {
+		result.Latitude = &lat
+		result.Longitude = &long
+	}
+
+	return result
+}
+
+func exifString(x *exif.Exif, name exif.FieldName) string {
+	tag, err := x.Get(name)
+	if err != nil {
+		return ""
+	}
+	value, err := tag.StringVal()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.Trim(value, "\x00"))
+}
+
+func exifFloat(x *exif.Exif, name exif.FieldName) float64 {
+	tag, err := x.Get(name)
+	if err != nil {
+		return 0
+	}
+	rat, err := tag.Rat(0)
+	if err != nil {
+		return 0
+	}
+	value, _ := rat.Float64()
+	return value
+}
@@ -0,0 +1,122 @@
+package inventory
+
+import (
+	"fmt"
+	"html"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/mmcdole/gofeed"
+)
+
+var directoryImageExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+}
+
+func imageDirectoryToDatabase(source config.Datasource) error {
+	parsedFeed, err := ScanDirectory(source.Path)
+	if err != nil {
+		return fmt.Errorf("scanning directory '%s': %w", source.Path, err)
+	}
+	parsedFeed.Title = source.Name
+
+	return storeImageFeed(parsedFeed, DirectoryFeedURL(source.Path), source)
+}
+
+// ScanDirectory reads all images below path and returns them as feed items.
+// Image URLs use the file scheme so uploaders can read them from disk.
+func ScanDirectory(path string) (*gofeed.Feed, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &gofeed.Feed{
+		Link:     DirectoryFeedURL(root),
+		FeedLink: DirectoryFeedURL(root),
+	}
+
+	err = filepath.WalkDir(root, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(entry.Name(), ".") && current != root {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || !directoryImageExtensions[extension(current)] {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		feed.Items = append(feed.Items, directoryItem(current, info.ModTime()))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first, like a regular feed
+	sort.SliceStable(feed.Items, func(i, j int) bool {
+		return feed.Items[i].PublishedParsed.After(*feed.Items[j].PublishedParsed)
+	})
+
+	return feed, nil
+}
+
+// DirectoryFeedURL returns the file URL used to identify a directory datasource.
+func DirectoryFeedURL(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func directoryItem(imagePath string, modTime time.Time) *gofeed.Item {
+	meta := readImageMetadata(imagePath)
+	imageURL := DirectoryFeedURL(imagePath)
+
+	title := meta.Title
+	if title == "" {
+		title = filepath.Base(imagePath)
+	}
+
+	published := modTime
+	if meta.Date != nil {
+		published = *meta.Date
+	}
+
+	// Mirror the homepage feed markup so alt text is extracted the same way
+	var description strings.Builder
+	description.WriteString(`<img src="` + html.EscapeString(imageURL) + `" alt="` + html.EscapeString(meta.Alt) + `">`)
+	if meta.Description != "" {
+		description.WriteString("<p>" + html.EscapeString(meta.Description) + "</p>")
+	}
+
+	return &gofeed.Item{
+		Title:           title,
+		Description:     description.String(),
+		Categories:      meta.Tags,
+		GUID:            imageURL,
+		Published:       published.Format(time.RFC3339),
+		PublishedParsed: &published,
+		Image:           &gofeed.Image{URL: imageURL, Title: meta.Alt},
+	}
+}
+
+func extension(path string) string {
+	return strings.ToLower(filepath.Ext(path))
+}
@@ -1,22 +1,32 @@
 package inventory
 
 import (
+	"fmt"
 	"log"
+	"slices"
 	"time"
 
+	"github.com/LNA-DEV/HomePageCompanion/config"
 	"github.com/LNA-DEV/HomePageCompanion/database"
 	"github.com/LNA-DEV/HomePageCompanion/models"
 	"github.com/mmcdole/gofeed"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func imageRssToDatabase(feedURL string, feedName string) {
+func imageRssToDatabase(source config.Datasource) error {
 	parser := gofeed.NewParser()
-	parsedFeed, err := parser.ParseURL(feedURL)
+	parsedFeed, err := parser.ParseURL(source.FeedURL)
 	if err != nil {
-		log.Fatalf("Error parsing feed: %v", err)
+		return fmt.Errorf("parsing feed: %w", err)
 	}
 
+	return storeImageFeed(parsedFeed, source.FeedURL, source)
+}
+
+// storeImageFeed upserts a parsed image feed and its items, soft-deleting items
+// that are no longer present.
+func storeImageFeed(parsedFeed *gofeed.Feed, feedURL string, source config.Datasource) error {
 	var authorsFeed []models.Author
 	for _, author := range parsedFeed.Authors {
 		authorsFeed = append(authorsFeed, models.Author{
@@ -26,7 +36,7 @@ func imageRssToDatabase(feedURL string, feedName string) {
 	}
 
 	feed := models.Feed{
-		FeedName:    feedName,
+		FeedName:    source.Name,
 		Title:       parsedFeed.Title,
 		Description: parsedFeed.Description,
 		Link:        parsedFeed.Link,
@@ -40,20 +50,21 @@ func imageRssToDatabase(feedURL string, feedName string) {
 
 	// Update or create feed
 	var existingFeed models.Feed
-	err = database.Db.Where("feed_url = ?", feedURL).First(&existingFeed).Error
+	err := database.Db.Where("feed_url = ?", feedURL).First(&existingFeed).Error
 	if err == gorm.ErrRecordNotFound {
 		if err := database.Db.Create(&feed).Error; err != nil {
-			log.Fatalf("Error saving feed: %v", err)
+			return fmt.Errorf("saving feed: %w", err)
 		}
 	} else if err == nil {
 		feed.ID = existingFeed.ID
 		database.Db.Model(&existingFeed).Updates(feed)
 	} else {
-		log.Fatalf("Error querying feed: %v", err)
+		return fmt.Errorf("querying feed: %w", err)
 	}
 
-	// Track seen GUIDs
+	// Track seen GUIDs and the items the search index needs to be updated for
 	seenGUIDs := make(map[string]bool)
+	var changedIDs []uint
 
 	for _, item := range parsedFeed.Items {
 		if item.GUID == "" {
@@ -75,19 +86,16 @@ func imageRssToDatabase(feedURL string, feedName string) {
 			})
 		}
 
-		var categories []models.Category
-		for _, cat := range item.Categories {
-			var category models.Category
-			if err := database.Db.FirstOrCreate(&category, models.Category{Name: cat}).Error; err == nil {
-				categories = append(categories, category)
-			} else {
-				log.Printf("Error saving category '%s': %v", cat, err)
-			}
+		imageURL := ""
+		if item.Image != nil {
+			imageURL = item.Image.URL
 		}
 
+		categories := resolveCategories(item.Categories)
+
 		// Lookup even soft-deleted items
 		var existingItem models.FeedItem
-		result := database.Db.Unscoped().Where("guid = ?", item.GUID).First(&existingItem)
+		result := database.Db.Unscoped().Preload("Categories").Where("guid = ?", item.GUID).First(&existingItem)
 
 		feedItem := models.FeedItem{
 			FeedID:      feed.ID,
@@ -98,14 +106,21 @@ func imageRssToDatabase(feedURL string, feedName string) {
 			GUID:        item.GUID,
 			Authors:     authors,
 			Categories:  categories,
-			ImageUrl:    item.Image.URL,
+			ImageUrl:    imageURL,
 			ItemType:    "image",
 		}
 
 		if result.Error == gorm.ErrRecordNotFound {
+			enrichImage(&feedItem, source)
+			if feedItem.ImageHash != "" {
+				warnAboutDuplicates(feedItem)
+			}
+
 			// Create new item
 			if err := database.Db.Create(&feedItem).Error; err != nil {
 				log.Printf("Error saving new item '%s': %v", item.Title, err)
+			} else {
+				changedIDs = append(changedIDs, feedItem.ID)
 			}
 		} else if result.Error == nil {
 			// If soft-deleted, undelete it
@@ -113,8 +128,38 @@ func imageRssToDatabase(feedURL string, feedName string) {
 				database.Db.Model(&existingItem).Update("DeletedAt", nil)
 			}
 
-			// Update item fields if changed
-			database.Db.Model(&existingItem).Updates(feedItem)
+			if needsImageEnrichment(existingItem, feedItem) {
+				enrichImage(&feedItem, source)
+				// Keep what is known about an unchanged image that failed to load
+				if feedItem.ImageFetchedAt == nil && feedItem.ImageUrl == existingItem.ImageUrl {
+					failedAt := feedItem.ImageFailedAt
+					keepImageMetadata(&feedItem, existingItem)
+					feedItem.ImageFailedAt = failedAt
+				}
+				if feedItem.ImageHash != "" && feedItem.ImageHash != existingItem.ImageHash {
+					warnAboutDuplicates(feedItem)
+				}
+			} else {
+				keepImageMetadata(&feedItem, existingItem)
+			}
+
+			changed := recordRevision(existingItem, feedItem)
+			categoriesChanged := slices.Contains(changed, "categories")
+			if len(changed) > 0 || existingItem.DeletedAt.Valid {
+				changedIDs = append(changedIDs, existingItem.ID)
+			}
+
+			// Write all columns, so values cleared by the source are cleared here too
+			if err := database.Db.Model(&existingItem).Select("*").Omit("ID", "CreatedAt", "DeletedAt", clause.Associations).Updates(feedItem).Error; err != nil {
+				log.Printf("Error updating item '%s': %v", item.Title, err)
+			}
+
+			// Updates only adds associations, drop categories the source removed
+			if categoriesChanged {
+				if err := database.Db.Model(&existingItem).Association("Categories").Replace(categories); err != nil {
+					log.Printf("Error updating categories of '%s': %v", item.Title, err)
+				}
+			}
 		} else {
 			log.Printf("Error checking item '%s': %v", item.Title, result.Error)
 		}
@@ -128,7 +173,37 @@ func imageRssToDatabase(feedURL string, feedName string) {
 		if !seenGUIDs[item.GUID] {
 			if err := database.Db.Delete(&item).Error; err != nil {
 				log.Printf("Error soft-deleting item '%s': %v", item.Title, err)
+			} else {
+				changedIDs = append(changedIDs, item.ID)
 			}
 		}
 	}
+
+	updateSearchIndex(changedIDs)
+	return nil
+}
+
+// needsImageEnrichment reports whether the image has to be downloaded again.
+// That is when it changed, was fetched before its type and size were stored or
+// could not be loaded and the retry interval passed.
+func needsImageEnrichment(existing models.FeedItem, item models.FeedItem) bool {
+	if existing.ImageUrl != item.ImageUrl {
+		return true
+	}
+	if existing.ImageFetchedAt != nil && existing.ImageType != "" {
+		return false
+	}
+	return existing.ImageFailedAt == nil || time.Since(*existing.ImageFailedAt) >= imageRetryInterval
+}
+
+// keepImageMetadata copies the metadata of an image that was not downloaded again.
+func keepImageMetadata(item *models.FeedItem, existing models.FeedItem) {
+	item.ImageWidth = existing.ImageWidth
+	item.ImageHeight = existing.ImageHeight
+	item.ImageType = existing.ImageType
+	item.ImageSize = existing.ImageSize
+	item.Exif = existing.Exif
+	item.ImageHash = existing.ImageHash
+	item.ImageFetchedAt = existing.ImageFetchedAt
+	item.ImageFailedAt = existing.ImageFailedAt
 }
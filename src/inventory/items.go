@@ -1,6 +1,8 @@
 package inventory
 
 import (
+	"time"
+
 	"github.com/LNA-DEV/HomePageCompanion/database"
 	"github.com/LNA-DEV/HomePageCompanion/models"
 	"gorm.io/gorm"
@@ -35,3 +37,72 @@ func GetFeedItemByID(id uint) (*models.FeedItem, error) {
 	}
 	return &item, nil
 }
+
+// GetFeedItemByGUID returns an item by its GUID.
+func GetFeedItemByGUID(guid string) (*models.FeedItem, error) {
+	var item models.FeedItem
+	if err := database.Db.
+		Preload("Categories").
+		Where("guid = ?", guid).
+		First(&item).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ItemExists reports whether a public item with the given name exists. Items
+// are named by their title, like publications and interactions.
+func ItemExists(name string) bool {
+	var count int64
+	database.Db.Model(&models.FeedItem{}).Scopes(PublicItemsScope).Where("title = ?", name).Count(&count)
+	return count > 0
+}
+
+// GetCaptureDates returns the EXIF capture dates of the given items, keyed by GUID.
+// Items without a capture date are omitted.
+func GetCaptureDates(guids []string) (map[string]time.Time, error) {
+	var items []models.FeedItem
+	if err := database.Db.
+		Select("guid", "exif_captured_at").
+		Where("guid IN ? AND exif_captured_at IS NOT NULL", guids).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	dates := make(map[string]time.Time)
+	for _, item := range items {
+		dates[item.GUID] = *item.Exif.CapturedAt
+	}
+	return dates, nil
+}
+
+// ListRecentItems returns the newest public items across the given feeds (all
+// feeds if none are given), optionally filtered by category name.
+func ListRecentItems(feedNames []string, category string, limit int) ([]models.FeedItem, error) {
+	var items []models.FeedItem
+	query := database.Db.Model(&models.FeedItem{}).Scopes(PublicItemsScope)
+
+	if len(feedNames) > 0 {
+		query = query.
+			Joins("JOIN feeds ON feeds.id = feed_items.feed_id").
+			Where("feeds.feed_name IN ?", feedNames)
+	}
+
+	if category != "" {
+		query = query.
+			Joins("JOIN feed_item_categories ON feed_item_categories.feed_item_id = feed_items.id").
+			Joins("JOIN categories ON categories.id = feed_item_categories.category_id").
+			Where("categories.name = ?", CanonicalCategoryName(category))
+	}
+
+	if err := query.
+		Order("feed_items.published DESC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -1,12 +1,63 @@
 package inventory
 
-import "github.com/LNA-DEV/HomePageCompanion/config"
+import (
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+)
+
+// syncMu prevents scheduled and push triggered syncs from running concurrently
+var syncMu sync.Mutex
 
 func PopulateDatabase() {
-	for _, item := range config.Data.Datasources.Rss {
-		switch item.ItemType {
-		case "image":
-			imageRssToDatabase(item.FeedURL, item.Name)
+	syncMu.Lock()
+	defer syncMu.Unlock()
+
+	for _, item := range Datasources() {
+		if err := syncDatasource(item); err != nil {
+			log.Printf("Error syncing datasource %s: %v", item.Name, err)
 		}
 	}
 }
+
+// ErrDatasourceNotFound is returned for names that are neither configured nor stored
+var ErrDatasourceNotFound = errors.New("datasource not found")
+
+// SyncDatasource syncs a single datasource by name.
+func SyncDatasource(name string) error {
+	source, ok := FindDatasource(name)
+	if !ok {
+		return ErrDatasourceNotFound
+	}
+
+	syncMu.Lock()
+	defer syncMu.Unlock()
+
+	return syncDatasource(source)
+}
+
+// SyncDatasourceInBackground syncs a datasource without blocking the caller
+// and logs failures, e.g. after an import or a push notification.
+func SyncDatasourceInBackground(name string) {
+	go func() {
+		if err := SyncDatasource(name); err != nil {
+			log.Printf("Error syncing datasource %s: %v", name, err)
+		}
+	}()
+}
+
+func syncDatasource(item config.Datasource) error {
+	if item.ItemType != "image" {
+		return nil
+	}
+
+	switch item.Type {
+	case config.DatasourceTypeRss:
+		return imageRssToDatabase(item)
+	case config.DatasourceTypeDirectory:
+		return imageDirectoryToDatabase(item)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rwcarlsen/goexif/exif"
+	"gopkg.in/yaml.v2"
+)
+
+// imageMetadata holds the descriptive fields of a local image, merged from
+// sidecar files and embedded metadata.
+type imageMetadata struct {
+	Title       string
+	Description string
+	Tags        []string
+	Alt         string
+	Date        *time.Time
+}
+
+// sidecar is the format of the optional YAML/JSON file next to an image.
+type sidecar struct {
+	Title       string   `yaml:"title" json:"title"`
+	Description string   `yaml:"description" json:"description"`
+	Tags        []string `yaml:"tags" json:"tags"`
+	Alt         string   `yaml:"alt" json:"alt"`
+	Date        string   `yaml:"date" json:"date"`
+}
+
+var sidecarExtensions = []string{".yaml", ".yml", ".json"}
+
+var sidecarDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// readImageMetadata collects metadata for an image. Sidecar values take
+// precedence over XMP, which takes precedence over EXIF.
+func readImageMetadata(imagePath string) imageMetadata {
+	var meta imageMetadata
+
+	data, err := os.ReadFile(imagePath)
+	if err == nil {
+		mergeMetadata(&meta, readExifMetadata(data))
+		mergeMetadata(&meta, readXmpMetadata(data))
+	}
+
+	if side, ok := readSidecar(imagePath); ok {
+		mergeMetadata(&meta, side)
+	}
+
+	return meta
+}
+
+// mergeMetadata overwrites fields in dst with the non-empty fields of src.
+func mergeMetadata(dst *imageMetadata, src imageMetadata) {
+	if src.Title != "" {
+		dst.Title = src.Title
+	}
+	if src.Description != "" {
+		dst.Description = src.Description
+	}
+	if len(src.Tags) > 0 {
+		dst.Tags = src.Tags
+	}
+	if src.Alt != "" {
+		dst.Alt = src.Alt
+	}
+	if src.Date != nil {
+		dst.Date = src.Date
+	}
+}
+
+func readSidecar(imagePath string) (imageMetadata, bool) {
+	base := strings.TrimSuffix(imagePath, extension(imagePath))
+
+	for _, ext := range sidecarExtensions {
+		for _, candidate := range []string{base + ext, imagePath + ext} {
+			data, err := os.ReadFile(candidate)
+			if err != nil {
+				continue
+			}
+
+			var side sidecar
+			if ext == ".json" {
+				err = json.Unmarshal(data, &side)
+			} else {
+				err = yaml.Unmarshal(data, &side)
+			}
+			if err != nil {
+				continue
+			}
+
+			meta := imageMetadata{
+				Title:       side.Title,
+				Description: side.Description,
+				Tags:        side.Tags,
+				Alt:         side.Alt,
+			}
+			for _, layout := range sidecarDateLayouts {
+				if parsed, err := time.Parse(layout, side.Date); err == nil {
+					meta.Date = &parsed
+					break
+				}
+			}
+			return meta, true
+		}
+	}
+
+	return imageMetadata{}, false
+}
+
+func readExifMetadata(data []byte) imageMetadata {
+	var meta imageMetadata
+
+	x, err := exif.Decode(bytes.NewReader(data))
+	if err != nil {
+		return meta
+	}
+
+	if taken, err := x.DateTime(); err == nil {
+		meta.Date = &taken
+	}
+
+	if tag, err := x.Get(exif.ImageDescription); err == nil {
+		if description, err := tag.StringVal(); err == nil {
+			meta.Description = strings.TrimSpace(strings.Trim(description, "\x00"))
+		}
+	}
+
+	return meta
+}
+
+var (
+	xmpPacketPattern = regexp.MustCompile(`(?s)<x:xmpmeta.*?</x:xmpmeta>`)
+	xmpItemPattern   = regexp.MustCompile(`(?s)<rdf:li[^>]*>(.*?)</rdf:li>`)
+)
+
+func readXmpMetadata(data []byte) imageMetadata {
+	var meta imageMetadata
+
+	packet := xmpPacketPattern.Find(data)
+	if packet == nil {
+		return meta
+	}
+
+	if titles := xmpValues(packet, "dc:title"); len(titles) > 0 {
+		meta.Title = titles[0]
+	}
+	if descriptions := xmpValues(packet, "dc:description"); len(descriptions) > 0 {
+		meta.Description = descriptions[0]
+	}
+	if alts := xmpValues(packet, "Iptc4xmpCore:AltTextAccessibility"); len(alts) > 0 {
+		meta.Alt = alts[0]
+	}
+	meta.Tags = xmpValues(packet, "dc:subject")
+
+	return meta
+}
+
+// xmpValues returns the rdf:li values of an XMP property.
+func xmpValues(packet []byte, property string) []string {
+	pattern := regexp.MustCompile(`(?s)<` + regexp.QuoteMeta(property) + `>(.*?)</` + regexp.QuoteMeta(property) + `>`)
+	match := pattern.FindSubmatch(packet)
+	if match == nil {
+		return nil
+	}
+
+	var values []string
+	for _, item := range xmpItemPattern.FindAllSubmatch(match[1], -1) {
+		value := strings.TrimSpace(html.UnescapeString(string(item[1])))
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
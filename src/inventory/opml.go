@@ -0,0 +1,205 @@
+package inventory
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+)
+
+// Opml is an OPML 2.0 document
+type Opml struct {
+	XMLName xml.Name    `xml:"opml"`
+	Version string      `xml:"version,attr"`
+	Head    OpmlHead    `xml:"head"`
+	Body    []OpmlEntry `xml:"body>outline"`
+}
+
+// OpmlHead is the head element of an OPML document
+type OpmlHead struct {
+	Title       string `xml:"title"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// OpmlEntry is an outline element. ItemType and Path are companion specific attributes.
+type OpmlEntry struct {
+	Type     string      `xml:"type,attr,omitempty"`
+	Text     string      `xml:"text,attr"`
+	Title    string      `xml:"title,attr,omitempty"`
+	XMLURL   string      `xml:"xmlUrl,attr,omitempty"`
+	Path     string      `xml:"path,attr,omitempty"`
+	ItemType string      `xml:"itemType,attr,omitempty"`
+	Children []OpmlEntry `xml:"outline"`
+}
+
+// DatasourceChange describes what an import does with a single datasource
+type DatasourceChange struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	FeedURL  string            `json:"feedUrl,omitempty"`
+	Path     string            `json:"path,omitempty"`
+	ItemType string            `json:"itemType"`
+	Previous *DatasourceValues `json:"previous,omitempty"`
+	Reason   string            `json:"reason,omitempty"`
+}
+
+// DatasourceValues are the values of a datasource before an update
+type DatasourceValues struct {
+	FeedURL  string `json:"feedUrl,omitempty"`
+	Path     string `json:"path,omitempty"`
+	ItemType string `json:"itemType"`
+}
+
+// ImportDiff is the result of an OPML import
+type ImportDiff struct {
+	DryRun    bool               `json:"dryRun"`
+	Added     []DatasourceChange `json:"added"`
+	Updated   []DatasourceChange `json:"updated"`
+	Unchanged []DatasourceChange `json:"unchanged"`
+	Skipped   []DatasourceChange `json:"skipped"`
+}
+
+// ExportOpml returns all datasources as an OPML document.
+func ExportOpml() Opml {
+	doc := Opml{
+		Version: "2.0",
+		Head: OpmlHead{
+			Title:       "HomePageCompanion datasources",
+			DateCreated: time.Now().Format(time.RFC1123Z),
+		},
+	}
+
+	for _, source := range Datasources() {
+		doc.Body = append(doc.Body, OpmlEntry{
+			Type:     source.Type,
+			Text:     source.Name,
+			Title:    source.Name,
+			XMLURL:   source.FeedURL,
+			Path:     source.Path,
+			ItemType: source.ItemType,
+		})
+	}
+
+	return doc
+}
+
+// ImportOpml stores the datasources of an OPML document in the database.
+// With dryRun set nothing is written and only the diff is returned.
+func ImportOpml(data []byte, dryRun bool) (*ImportDiff, error) {
+	var doc Opml
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	diff := &ImportDiff{
+		DryRun:    dryRun,
+		Added:     []DatasourceChange{},
+		Updated:   []DatasourceChange{},
+		Unchanged: []DatasourceChange{},
+		Skipped:   []DatasourceChange{},
+	}
+
+	existing := make(map[string]config.Datasource)
+	for _, source := range Datasources() {
+		existing[source.Name] = source
+	}
+
+	for _, entry := range flattenOpml(doc.Body) {
+		change, err := entryToChange(entry)
+		if err != nil {
+			change.Reason = err.Error()
+			diff.Skipped = append(diff.Skipped, change)
+			continue
+		}
+
+		current, exists := existing[change.Name]
+		switch {
+		case !exists:
+			diff.Added = append(diff.Added, change)
+		case current.Type == change.Type && current.FeedURL == change.FeedURL && current.Path == change.Path && current.ItemType == change.ItemType:
+			diff.Unchanged = append(diff.Unchanged, change)
+			continue
+		case IsConfigDatasource(change.Name):
+			change.Reason = "defined in config file"
+			diff.Skipped = append(diff.Skipped, change)
+			continue
+		default:
+			change.Previous = &DatasourceValues{FeedURL: current.FeedURL, Path: current.Path, ItemType: current.ItemType}
+			diff.Updated = append(diff.Updated, change)
+		}
+		existing[change.Name] = config.Datasource{Name: change.Name, Type: change.Type, FeedURL: change.FeedURL, Path: change.Path, ItemType: change.ItemType}
+
+		if dryRun {
+			continue
+		}
+
+		var stored models.Datasource
+		database.Db.Where("name = ?", change.Name).FirstOrInit(&stored)
+		stored.Name = change.Name
+		stored.Type = change.Type
+		stored.FeedURL = change.FeedURL
+		stored.Path = change.Path
+		stored.ItemType = change.ItemType
+		if err := database.Db.Save(&stored).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return diff, nil
+}
+
+// DeleteStoredDatasource removes a database managed datasource. Its feed and items are kept.
+func DeleteStoredDatasource(name string) (bool, error) {
+	result := database.Db.Unscoped().Where("name = ?", name).Delete(&models.Datasource{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func flattenOpml(entries []OpmlEntry) []OpmlEntry {
+	var flat []OpmlEntry
+	for _, entry := range entries {
+		if entry.XMLURL != "" || entry.Path != "" {
+			flat = append(flat, entry)
+		}
+		flat = append(flat, flattenOpml(entry.Children)...)
+	}
+	return flat
+}
+
+func entryToChange(entry OpmlEntry) (DatasourceChange, error) {
+	name := strings.TrimSpace(entry.Text)
+	if name == "" {
+		name = strings.TrimSpace(entry.Title)
+	}
+
+	change := DatasourceChange{
+		Name:     name,
+		Type:     config.DatasourceTypeRss,
+		FeedURL:  entry.XMLURL,
+		Path:     entry.Path,
+		ItemType: entry.ItemType,
+	}
+	if entry.Type == config.DatasourceTypeDirectory {
+		change.Type = config.DatasourceTypeDirectory
+		change.FeedURL = ""
+	} else {
+		change.Path = ""
+	}
+	if change.ItemType == "" {
+		change.ItemType = "image"
+	}
+
+	if name == "" {
+		return change, errors.New("outline without name")
+	}
+	if change.Type == config.DatasourceTypeRss && change.FeedURL == "" {
+		return change, errors.New("outline without xmlUrl")
+	}
+	if change.Type == config.DatasourceTypeDirectory && change.Path == "" {
+		return change, errors.New("outline without path")
+	}
+	return change, nil
+}
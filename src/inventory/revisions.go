@@ -0,0 +1,70 @@
+package inventory
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+)
+
+// recordRevision stores the previous values of an item if any tracked field changed.
+// It returns the changed fields.
+func recordRevision(existing models.FeedItem, updated models.FeedItem) []string {
+	previousCategories := categoryNames(existing.Categories)
+	categoriesChanged := previousCategories != categoryNames(updated.Categories)
+
+	var changed []string
+	if existing.Title != updated.Title {
+		changed = append(changed, "title")
+	}
+	if existing.Description != updated.Description {
+		changed = append(changed, "description")
+	}
+	if categoriesChanged {
+		changed = append(changed, "categories")
+	}
+	if existing.ImageUrl != updated.ImageUrl {
+		changed = append(changed, "imageUrl")
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	revision := models.FeedItemRevision{
+		FeedItemID:    existing.ID,
+		Title:         existing.Title,
+		Description:   existing.Description,
+		Categories:    previousCategories,
+		ImageUrl:      existing.ImageUrl,
+		ChangedFields: strings.Join(changed, ","),
+	}
+	if err := database.Db.Create(&revision).Error; err != nil {
+		log.Printf("Error saving revision for '%s': %v", existing.Title, err)
+	}
+
+	return changed
+}
+
+// ListRevisions returns the revisions of an item, newest first.
+func ListRevisions(itemID uint) ([]models.FeedItemRevision, error) {
+	var revisions []models.FeedItemRevision
+	if err := database.Db.
+		Where("feed_item_id = ?", itemID).
+		Order("created_at DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func categoryNames(categories []models.Category) string {
+	names := make([]string, 0, len(categories))
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,180 @@
+package inventory
+
+import (
+	"html"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+)
+
+// ftsAvailable is false if the sqlite driver was built without the
+// sqlite_fts5 tag. Search then falls back to LIKE queries.
+var ftsAvailable bool
+
+var (
+	htmlTagPattern = regexp.MustCompile(`(?s)<[^>]*>`)
+	altPattern     = regexp.MustCompile(`(?i)\balt\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// SearchResult is a feed item matching a search query
+type SearchResult struct {
+	Item  models.FeedItem
+	Score float64 // Higher is more relevant, always 0 without FTS5
+}
+
+// InitSearchIndex creates the FTS5 table and fills it if it is out of sync
+// with the feed items.
+func InitSearchIndex() {
+	err := database.Db.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS feed_items_fts USING fts5(
+		title, description, categories, alt,
+		tokenize = 'unicode61 remove_diacritics 2'
+	)`).Error
+	if err != nil {
+		log.Printf("Full-text search unavailable, falling back to LIKE queries: %v", err)
+		return
+	}
+	ftsAvailable = true
+
+	var indexed, items int64
+	database.Db.Raw("SELECT COUNT(*) FROM feed_items_fts").Scan(&indexed)
+	database.Db.Model(&models.FeedItem{}).Count(&items)
+	if indexed == items {
+		return
+	}
+
+	log.Printf("Rebuilding search index (%d of %d items indexed)", indexed, items)
+	if err := database.Db.Exec("DELETE FROM feed_items_fts").Error; err != nil {
+		log.Printf("Error clearing search index: %v", err)
+		return
+	}
+
+	var ids []uint
+	database.Db.Model(&models.FeedItem{}).Pluck("id", &ids)
+	updateSearchIndex(ids)
+}
+
+// updateSearchIndex re-indexes the given items. Soft-deleted items are removed.
+func updateSearchIndex(itemIDs []uint) {
+	if !ftsAvailable || len(itemIDs) == 0 {
+		return
+	}
+
+	var items []models.FeedItem
+	if err := database.Db.Unscoped().Preload("Categories").Where("id IN ?", itemIDs).Find(&items).Error; err != nil {
+		log.Printf("Error loading items for search index: %v", err)
+		return
+	}
+
+	for _, item := range items {
+		if err := database.Db.Exec("DELETE FROM feed_items_fts WHERE rowid = ?", item.ID).Error; err != nil {
+			log.Printf("Error updating search index for '%s': %v", item.Title, err)
+			continue
+		}
+		if item.DeletedAt.Valid {
+			continue
+		}
+
+		err := database.Db.Exec(
+			"INSERT INTO feed_items_fts (rowid, title, description, categories, alt) VALUES (?, ?, ?, ?, ?)",
+			item.ID, item.Title, plainText(item.Description), categoryNames(item.Categories), altTexts(item.Description),
+		).Error
+		if err != nil {
+			log.Printf("Error updating search index for '%s': %v", item.Title, err)
+		}
+	}
+}
+
+// SearchItems returns the items matching all terms of the query, best matches first.
+func SearchItems(query string, limit int) ([]SearchResult, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return []SearchResult{}, nil
+	}
+
+	if !ftsAvailable {
+		return searchItemsLike(terms, limit)
+	}
+
+	// Every term is quoted to avoid FTS syntax errors and matched as prefix
+	var match []string
+	for _, term := range terms {
+		match = append(match, `"`+strings.ReplaceAll(term, `"`, `""`)+`"*`)
+	}
+
+	// Weights for title, description, categories and alt text
+	var rows []struct {
+		ID    uint
+		Score float64
+	}
+	err := database.Db.Raw(
+		"SELECT rowid AS id, -bm25(feed_items_fts, 10.0, 1.0, 5.0, 3.0) AS score FROM feed_items_fts WHERE feed_items_fts MATCH ? ORDER BY score DESC LIMIT ?",
+		strings.Join(match, " "), limit,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	var items []models.FeedItem
+	if err := database.Db.Preload("Categories").Find(&items, ids).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]models.FeedItem)
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	results := []SearchResult{}
+	for _, row := range rows {
+		if item, ok := byID[row.ID]; ok {
+			results = append(results, SearchResult{Item: item, Score: row.Score})
+		}
+	}
+	return results, nil
+}
+
+func searchItemsLike(terms []string, limit int) ([]SearchResult, error) {
+	query := database.Db.Preload("Categories")
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		query = query.Where(
+			"title LIKE ? OR description LIKE ? OR id IN (SELECT feed_item_categories.feed_item_id FROM feed_item_categories JOIN categories ON categories.id = feed_item_categories.category_id WHERE categories.name LIKE ?)",
+			pattern, pattern, pattern,
+		)
+	}
+
+	var items []models.FeedItem
+	if err := query.Order("published DESC").Limit(limit).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	results := []SearchResult{}
+	for _, item := range items {
+		results = append(results, SearchResult{Item: item})
+	}
+	return results, nil
+}
+
+// plainText strips HTML tags and entities from a description
+func plainText(description string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(htmlTagPattern.ReplaceAllString(description, " "))), " ")
+}
+
+// altTexts returns the alt attributes of all images in a description
+func altTexts(description string) string {
+	var alts []string
+	for _, match := range altPattern.FindAllStringSubmatch(description, -1) {
+		alt := match[1] + match[2]
+		if alt != "" {
+			alts = append(alts, html.UnescapeString(alt))
+		}
+	}
+	return strings.Join(alts, " ")
+}
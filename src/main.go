@@ -11,11 +11,16 @@ import (
 	"github.com/LNA-DEV/HomePageCompanion/backfill"
 	"github.com/LNA-DEV/HomePageCompanion/config"
 	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/feedgen"
 	"github.com/LNA-DEV/HomePageCompanion/interactions"
 	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/LNA-DEV/HomePageCompanion/milestones"
 	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/LNA-DEV/HomePageCompanion/security"
+	"github.com/LNA-DEV/HomePageCompanion/syndication"
 	"github.com/LNA-DEV/HomePageCompanion/webmention"
 	"github.com/LNA-DEV/HomePageCompanion/webpush"
+	"github.com/LNA-DEV/HomePageCompanion/websub"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/robfig/cron"
@@ -29,14 +34,25 @@ func main() {
 
 	// Database
 	database.LoadDatabase()
-	database.MigrateModels([]interface{}{models.Webmention{}, models.AutoUploadItem{}, models.VAPIDKey{}, models.NotificationSubscription{}, models.Feed{}, models.FeedItem{}, models.Author{}, models.Category{}, models.Interaction{}, models.NativeLike{}})
+	database.MigrateModels([]interface{}{models.Webmention{}, models.AutoUploadItem{}, models.VAPIDKey{}, models.NotificationSubscription{}, models.Feed{}, models.FeedItem{}, models.FeedItemRevision{}, models.Author{}, models.Category{}, models.CategoryAlias{}, models.CategoryHashtag{}, models.Interaction{}, models.InteractionSnapshot{}, models.RemoteReaction{}, models.NativeLike{}, models.Comment{}, models.IPHashSalt{}, models.Milestone{}, models.WebSubSubscription{}, models.Datasource{}})
+
+	database.DropIndex(&models.RemoteReaction{}, "idx_remote_reaction")
+	autouploader.AssignPublicationTargets()
+	interactions.RecountNativeInteractions()
+	security.LoadIPHashSalts()
+	milestones.Initialize()
 
 	// Inventory
+	inventory.InitSearchIndex()
+	inventory.NormalizeCategories()
 	inventory.PopulateDatabase()
 
 	// Webpush
 	webpush.LoadVAPIDKeys()
 
+	// WebSub
+	go websub.RenewSubscriptions()
+
 	// Cron setup
 	c := cron.New()
 
@@ -49,10 +65,19 @@ func main() {
 	c.AddFunc("0 */5 * * * *", func() { config.LoadConfig() })
 	c.AddFunc("0 * */1 * * *", func() { inventory.PopulateDatabase() })
 	c.AddFunc("0 0 * * * *", func() { interactions.FetchAndStoreInteractions() })
+	c.AddFunc("0 10 3 * * *", func() { interactions.CompactSnapshots() })
+	c.AddFunc("0 20 3 * * *", func() {
+		security.RotateIPHashSalt()
+		interactions.ApplyPrivacyRetention()
+	})
+	c.AddFunc("0 0 9 * * 1", func() { milestones.AnnounceTopOfWeek() })
+	c.AddFunc("0 30 */6 * * *", func() { websub.RenewSubscriptions() })
+	c.AddFunc("0 15 * * * *", func() { syndication.ResolveInstagramPermalinks() })
 	c.Start()
 
 	// Router config
 	router := gin.Default()
+	security.TrustProxies(router)
 
 	// Build regex pattern dynamically
 	subdomainRegex := regexp.MustCompile(`^https?://([a-z0-9-]+\.)*` + regexp.QuoteMeta(config.Data.Security.Domain) + `(:[0-9]+)?$`)
@@ -72,20 +97,47 @@ func main() {
 
 	router.Use(cors.New(config))
 
+	// Limits for unauthenticated writes, per IP hash
+	likeLimit := security.RateLimit(30, time.Minute)
+	commentLimit := security.RateLimit(5, time.Minute)
+	challengeLimit := security.RateLimit(30, time.Minute)
+	webmentionLimit := security.RateLimit(10, time.Minute)
+	subscribeLimit := security.RateLimit(5, time.Minute)
+	privacyLimit := security.RateLimit(10, time.Minute)
+
 	// API routes
 	api := router.Group("/api")
 	{
-		api.POST("/webmention", webmention.HandleWebmention)
+		api.POST("/webmention", security.MaxBodySize(smallBodyLimit), webmentionLimit, webmention.HandleWebmention)
 		api.POST("/upload/:connectionName", validateAPIKey(), uploadNext)
 		api.GET("/webpush/vapidkey", getVapidPublicKey)
-		api.POST("/webpush/subscribe", webpush.SubscribeHandler())
+		api.POST("/webpush/subscribe", security.MaxBodySize(smallBodyLimit), subscribeLimit, webpush.SubscribeHandler())
 		api.POST("/webpush/broadcast", validateAPIKey(), broadcast)
 		api.GET("/interactions/post/:target_name/:item_name", interactions.HandleInteraction)
-		api.POST("/interactions/native/:item_name/like", interactions.HandleNativeLike)
-		api.DELETE("/interactions/native/:item_name/like", interactions.HandleNativeUnlike)
+		api.GET("/interactions/challenge", challengeLimit, security.HandleChallenge)
+		api.POST("/interactions/native/:item_name/like", security.MaxBodySize(smallBodyLimit), likeLimit, interactions.HandleNativeLike)
+		api.DELETE("/interactions/native/:item_name/like", security.MaxBodySize(smallBodyLimit), likeLimit, interactions.HandleNativeUnlike)
 		api.GET("/interactions/native/:item_name/status", interactions.HandleNativeLikeStatus)
+		api.POST("/interactions/native/:item_name/reactions", security.MaxBodySize(smallBodyLimit), likeLimit, interactions.HandleNativeReact)
+		api.DELETE("/interactions/native/:item_name/reactions", security.MaxBodySize(smallBodyLimit), likeLimit, interactions.HandleNativeUnreact)
+		api.GET("/interactions/reactions/:item_name", interactions.HandleReactions)
+		api.GET("/interactions/comments/:item_name", interactions.HandleGetComments)
+		api.POST("/interactions/comments/:item_name", security.MaxBodySize(commentBodyLimit), commentLimit, interactions.HandlePostComment)
+		api.GET("/interactions/stream", interactions.HandleStream)
+		api.GET("/interactions/batch", interactions.HandleBatchInteractions)
+		api.POST("/privacy/export", security.MaxBodySize(smallBodyLimit), privacyLimit, interactions.HandlePrivacyExport)
+		api.POST("/privacy/erase", security.MaxBodySize(smallBodyLimit), privacyLimit, interactions.HandlePrivacyErase)
 		api.POST("/interactions/fetch", validateAPIKey(), triggerInteractionsFetch)
 		api.POST("/backfill", validateAPIKey(), triggerBackfill)
+		api.GET("/syndication", syndication.HandleBatchSyndication)
+		api.GET("/syndication/:item_name", syndication.HandleGetSyndication)
+		api.GET("/items", inventory.HandleListItems)
+		api.GET("/items/:id", inventory.HandleGetItem)
+		api.GET("/feeds/rss", feedgen.HandleRss)
+		api.GET("/feeds/atom", feedgen.HandleAtom)
+		api.GET("/feeds/json", feedgen.HandleJSONFeed)
+		api.GET("/websub/callback/:id", websub.HandleVerification)
+		api.POST("/websub/callback/:id", websub.HandleNotification)
 	}
 
 	// Admin API routes
@@ -97,6 +149,12 @@ func main() {
 	router.Run(":8080")
 }
 
+// Request body limits of public endpoints
+const (
+	smallBodyLimit   = 4 << 10
+	commentBodyLimit = 16 << 10
+)
+
 func broadcast(c *gin.Context) {
 	var notif models.Notification
 	if err := c.ShouldBindJSON(&notif); err != nil {
@@ -0,0 +1,204 @@
+package milestones
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/LNA-DEV/HomePageCompanion/webpush"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	KindLikes     = "likes"
+	KindTopOfWeek = "topOfWeek"
+)
+
+var defaultLikeThresholds = []int{1, 10, 100}
+
+// Initialize records the thresholds existing items already passed without
+// notifying, so enabling milestones does not flood the admins
+func Initialize() {
+	var count int64
+	database.Db.Model(&models.Milestone{}).Count(&count)
+	if count > 0 {
+		return
+	}
+
+	var items []string
+	if err := database.Db.Model(&models.Interaction{}).Distinct("item_name").Pluck("item_name", &items).Error; err != nil {
+		log.Printf("Error loading items for milestones: %v", err)
+		return
+	}
+	for _, itemName := range items {
+		recordLikeMilestones(itemName)
+	}
+	log.Printf("Initialized milestones of %d items", len(items))
+}
+
+// CheckItem notifies the admins when the total likes of an item passed a
+// threshold for the first time. Only the highest new threshold is announced.
+func CheckItem(itemName string) {
+	reached, likes := recordLikeMilestones(itemName)
+	if reached == 0 {
+		return
+	}
+
+	go webpush.NotifyAdmins(models.Notification{
+		Title: "Milestone: " + likesText(reached),
+		Body:  fmt.Sprintf("%s now has %s", itemName, likesText(likes)),
+	})
+}
+
+// AnnounceTopOfWeek notifies the admins about the item that gained the most
+// likes during the last seven days
+func AnnounceTopOfWeek() {
+	now := time.Now()
+	year, number := now.AddDate(0, 0, -1).ISOWeek()
+	week := fmt.Sprintf("%d-W%02d", year, number)
+
+	var existing int64
+	database.Db.Model(&models.Milestone{}).Where("kind = ? AND level = ?", KindTopOfWeek, week).Count(&existing)
+	if existing > 0 {
+		return
+	}
+
+	gains, err := likesGainedSince(now.AddDate(0, 0, -7))
+	if err != nil {
+		log.Printf("Error calculating likes of the week: %v", err)
+		return
+	}
+
+	top, topLikes := "", 0
+	for itemName, likes := range gains {
+		if likes > topLikes || (likes == topLikes && itemName < top) {
+			top, topLikes = itemName, likes
+		}
+	}
+	if topLikes == 0 {
+		return
+	}
+
+	milestone := models.Milestone{ItemName: top, Kind: KindTopOfWeek, Level: week, Likes: topLikes}
+	if err := database.Db.Create(&milestone).Error; err != nil {
+		log.Printf("Error recording top post of %s: %v", week, err)
+		return
+	}
+
+	webpush.NotifyAdmins(models.Notification{
+		Title: "Top post of " + week,
+		Body:  fmt.Sprintf("%s gained %s this week", top, likesText(topLikes)),
+	})
+}
+
+// recordLikeMilestones stores the thresholds the item passed that were not
+// recorded yet. It returns the highest new one and the total likes.
+func recordLikeMilestones(itemName string) (int, int) {
+	var likes int
+	if err := database.Db.Model(&models.Interaction{}).Where("item_name = ?", itemName).
+		Select("COALESCE(SUM(like_count), 0)").Scan(&likes).Error; err != nil {
+		log.Printf("Error summing likes of %s: %v", itemName, err)
+		return 0, 0
+	}
+
+	reached := 0
+	for _, threshold := range likeThresholds() {
+		if likes < threshold {
+			continue
+		}
+
+		milestone := models.Milestone{ItemName: itemName, Kind: KindLikes, Level: strconv.Itoa(threshold), Likes: likes}
+		result := database.Db.Clauses(clause.OnConflict{DoNothing: true}).Create(&milestone)
+		if result.Error != nil {
+			log.Printf("Error recording milestone of %s: %v", itemName, result.Error)
+			continue
+		}
+		if result.RowsAffected > 0 && threshold > reached {
+			reached = threshold
+		}
+	}
+
+	return reached, likes
+}
+
+// likesGainedSince sums the likes each item gained on all platforms. Remote
+// counts are compared to the last snapshot before since, native likes are
+// counted directly.
+func likesGainedSince(since time.Time) (map[string]int, error) {
+	gains := make(map[string]int)
+
+	var interactions []models.Interaction
+	if err := database.Db.Where("platform != ?", "native").Find(&interactions).Error; err != nil {
+		return nil, err
+	}
+	for _, interaction := range interactions {
+		baseline, err := likesAt(interaction, since)
+		if err != nil {
+			return nil, err
+		}
+		if gain := interaction.LikeCount - baseline; gain > 0 {
+			gains[interaction.ItemName] += gain
+		}
+	}
+
+	var native []struct {
+		ItemName string
+		Count    int
+	}
+	if err := database.Db.Model(&models.NativeLike{}).Select("item_name, COUNT(*) AS count").
+		Where("created_at >= ? AND reaction = ?", since, "like").Group("item_name").Scan(&native).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range native {
+		gains[row.ItemName] += row.Count
+	}
+
+	return gains, nil
+}
+
+// likesAt returns the like count of an interaction at the given time. New
+// interactions started at zero, older ones without history at their first
+// snapshot afterwards.
+func likesAt(interaction models.Interaction, at time.Time) (int, error) {
+	query := func() *gorm.DB {
+		return database.Db.Where("item_name = ? AND platform = ? AND target_name = ? AND metric = ?", interaction.ItemName, interaction.Platform, interaction.TargetName, "likes")
+	}
+
+	var snapshots []models.InteractionSnapshot
+	if err := query().Where("created_at < ?", at).Order("created_at DESC").Limit(1).Find(&snapshots).Error; err != nil {
+		return 0, err
+	}
+	if len(snapshots) > 0 {
+		return snapshots[0].Value, nil
+	}
+	if !interaction.CreatedAt.Before(at) {
+		return 0, nil
+	}
+
+	if err := query().Order("created_at").Limit(1).Find(&snapshots).Error; err != nil {
+		return 0, err
+	}
+	if len(snapshots) > 0 {
+		return snapshots[0].Value, nil
+	}
+	return interaction.LikeCount, nil
+}
+
+func likeThresholds() []int {
+	if len(config.Data.Milestones.LikeThresholds) == 0 {
+		return defaultLikeThresholds
+	}
+	return config.Data.Milestones.LikeThresholds
+}
+
+func likesText(likes int) string {
+	if likes == 1 {
+		return "1 like"
+	}
+	return fmt.Sprintf("%d likes", likes)
+}
@@ -3,11 +3,12 @@ package models
 import "time"
 
 type AutoUploadItem struct {
-	ID        uint   `gorm:"primaryKey"`
-	Platform  string `gorm:"index"`
-	ItemName  string `gorm:"index"`
-	PostUrl   *string
-	VersionId *string
-	PostId    *string
-	CreatedAt time.Time
+	ID         uint   `gorm:"primaryKey"`
+	Platform   string `gorm:"index"`
+	TargetName string `gorm:"index"`
+	ItemName   string `gorm:"index"`
+	PostUrl    *string
+	VersionId  *string
+	PostId     *string
+	CreatedAt  time.Time
 }
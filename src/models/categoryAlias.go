@@ -0,0 +1,10 @@
+package models
+
+import "gorm.io/gorm"
+
+// CategoryAlias maps an alternative (normalized) category name to a category
+type CategoryAlias struct {
+	gorm.Model
+	Alias      string `gorm:"uniqueIndex;not null"`
+	CategoryID uint   `gorm:"index"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "gorm.io/gorm"
+
+// CategoryHashtag overrides the hashtag of a category on one platform
+type CategoryHashtag struct {
+	gorm.Model
+	CategoryID uint   `gorm:"uniqueIndex:idx_category_platform"`
+	Platform   string `gorm:"uniqueIndex:idx_category_platform"`
+	Hashtag    string
+}
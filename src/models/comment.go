@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Comment is a first-party comment on an item, shown once approved
+type Comment struct {
+	ID          uint   `gorm:"primaryKey"`
+	ItemName    string `gorm:"index;not null"`
+	Name        string `gorm:"not null"`
+	Website     string
+	Text        string `gorm:"not null"`
+	Status      string `gorm:"index;not null;default:pending"` // "pending", "approved", "spam" or "rejected"
+	IPHash      string `gorm:"column:ip_hash;index;not null"`  // SHA256 hash of IP + salt for GDPR compliance
+	SaltVersion uint   // Version of the salt the IP hash was made with
+	Token       string `gorm:"index;not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
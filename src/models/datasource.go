@@ -0,0 +1,13 @@
+package models
+
+import "gorm.io/gorm"
+
+// Datasource is a datasource managed through the admin API instead of the config file
+type Datasource struct {
+	gorm.Model
+	Name     string `gorm:"uniqueIndex;not null"`
+	Type     string `gorm:"not null"`
+	FeedURL  string
+	Path     string
+	ItemType string
+}
@@ -33,6 +33,30 @@ type FeedItem struct {
 	Published   time.Time
 	GUID        string   `gorm:"uniqueIndex"`
 	Authors     []Author `gorm:"foreignKey:FeedItemID"`
+	ImageWidth  int
+	ImageHeight int
+	ImageType   string    // MIME type, e.g. "image/jpeg"
+	ImageSize   int       // in bytes
+	Exif        ImageExif `gorm:"embedded;embeddedPrefix:exif_"`
+	ImageHash   string    `gorm:"index"` // Perceptual hash (goimagehash)
+	// Set once the image was downloaded and its metadata extracted
+	ImageFetchedAt *time.Time
+	// Set when the image could not be loaded, it is retried after a day
+	ImageFailedAt *time.Time
+}
+
+// ImageExif holds the EXIF metadata extracted from an item's image
+type ImageExif struct {
+	CameraMake   string
+	CameraModel  string
+	LensModel    string
+	FocalLength  float64 // in mm
+	ExposureTime string  // e.g. "1/250"
+	FNumber      float64
+	ISO          int
+	CapturedAt   *time.Time
+	Latitude     *float64
+	Longitude    *float64
 }
 
 type Author struct {
@@ -45,6 +69,9 @@ type Author struct {
 
 type Category struct {
 	gorm.Model
-	Name      string     `gorm:"uniqueIndex"`
+	Name      string     `gorm:"uniqueIndex"` // Normalized, see inventory.NormalizeCategory
+	Blocked   bool       // Never used as hashtag
 	FeedItems []FeedItem `gorm:"many2many:feed_item_categories"`
+	Aliases   []CategoryAlias
+	Hashtags  []CategoryHashtag
 }
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// FeedItemRevision stores the previous values of a feed item whenever the source changes them
+type FeedItemRevision struct {
+	ID            uint `gorm:"primaryKey"`
+	FeedItemID    uint `gorm:"index;not null"`
+	Title         string
+	Description   string
+	Categories    string // Comma separated category names
+	ImageUrl      string
+	ChangedFields string // Comma separated names of the fields that changed
+	CreatedAt     time.Time
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// InteractionSnapshot is the value of an interaction metric at the time of a fetch
+type InteractionSnapshot struct {
+	ID         uint   `gorm:"primaryKey"`
+	ItemName   string `gorm:"index:idx_snapshot_item_time"`
+	Platform   string `gorm:"index"`
+	TargetName string
+	Metric     string // e.g. "likes"
+	Value      int
+	CreatedAt  time.Time `gorm:"index:idx_snapshot_item_time;index"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// IPHashSalt is one version of the salt used to hash visitor IP addresses.
+// The ID is the version stored next to each hash.
+type IPHashSalt struct {
+	ID        uint   `gorm:"primaryKey"`
+	Salt      string `gorm:"not null"`
+	CreatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Milestone records that an item reached a like threshold or was the top post
+// of a week, so admins are notified only once
+type Milestone struct {
+	ID        uint      `gorm:"primaryKey"`
+	ItemName  string    `gorm:"uniqueIndex:idx_milestone"`
+	Kind      string    `gorm:"uniqueIndex:idx_milestone"` // "likes" or "topOfWeek"
+	Level     string    `gorm:"uniqueIndex:idx_milestone"` // Threshold or ISO week, e.g. "100" or "2025-W07"
+	Likes     int       // Likes when the milestone was reached, or gained during the week
+	CreatedAt time.Time `gorm:"index"`
+}
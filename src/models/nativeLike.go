@@ -3,9 +3,11 @@ package models
 import "time"
 
 type NativeLike struct {
-	ID        uint   `gorm:"primaryKey"`
-	ItemName  string `gorm:"index;not null"`
-	IPHash    string `gorm:"column:ip_hash;index;not null"` // SHA256 hash of IP + salt for GDPR compliance
-	Token     string `gorm:"index;not null"`
-	CreatedAt time.Time
+	ID          uint   `gorm:"primaryKey"`
+	ItemName    string `gorm:"index;not null"`
+	Reaction    string `gorm:"index;not null;default:like"`   // "like" or one of the configured emoji
+	IPHash      string `gorm:"column:ip_hash;index;not null"` // SHA256 hash of IP + salt for GDPR compliance
+	SaltVersion uint   // Version of the salt the IP hash was made with
+	Token       string `gorm:"index;not null"`
+	CreatedAt   time.Time
 }
@@ -8,4 +8,5 @@ type NotificationSubscription struct {
 	ExpirationTime *int64
 	Auth           string
 	P256dh         string
+	Admin          bool // Receives notifications meant for the site owner
 }
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// RemoteReaction is a like, repost or reply to a publication on another platform
+type RemoteReaction struct {
+	ID          uint   `gorm:"primaryKey"`
+	ItemName    string `gorm:"uniqueIndex:idx_remote_reaction_target;index"`
+	Platform    string `gorm:"uniqueIndex:idx_remote_reaction_target"`
+	TargetName  string `gorm:"uniqueIndex:idx_remote_reaction_target"`
+	Type        string `gorm:"uniqueIndex:idx_remote_reaction_target"` // "like", "repost" or "reply"
+	ActorID     string `gorm:"uniqueIndex:idx_remote_reaction_target"` // DID or platform account ID
+	RemoteID    string `gorm:"uniqueIndex:idx_remote_reaction_target"` // ID of the reply, empty for likes and reposts
+	ActorHandle string
+	ActorName   string
+	ActorAvatar string
+	ActorURL    string
+	Content     string // Plain text of replies
+	URL         string // Link to the reply
+	ReactedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// WebSubSubscription tracks the hub subscription of a feed
+type WebSubSubscription struct {
+	ID             uint   `gorm:"primaryKey"`
+	FeedName       string `gorm:"index"`
+	FeedURL        string `gorm:"uniqueIndex;not null"`
+	Topic          string
+	Hub            string
+	Secret         string
+	LeaseExpiresAt *time.Time
+	VerifiedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,152 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/gin-gonic/gin"
+)
+
+const challengeLifetime = 5 * time.Minute
+
+var (
+	ErrInvalidChallenge = errors.New("invalid challenge")
+	ErrChallengeUsed    = errors.New("challenge already used")
+	ErrInvalidProof     = errors.New("invalid proof of work")
+)
+
+// Challenges are signed instead of stored. A restart invalidates open ones,
+// which only costs the visitor a new challenge.
+var challengeKey = func() []byte {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return key
+}()
+
+var (
+	usedMu sync.Mutex
+	used   = make(map[string]time.Time)
+)
+
+// Proof is the solution of a challenge sent along with a like
+type Proof struct {
+	Challenge string `json:"challenge"`
+	Nonce     string `json:"nonce"`
+}
+
+// ChallengeResponse tells the client what to solve. The client searches a
+// nonce so that SHA-256(challenge + ":" + nonce) starts with difficulty zero bits.
+type ChallengeResponse struct {
+	Challenge  string     `json:"challenge,omitempty"`
+	Difficulty int        `json:"difficulty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+}
+
+// ProofOfWorkDifficulty returns the configured difficulty, 0 if proof of work is disabled
+func ProofOfWorkDifficulty() int {
+	return config.Data.Security.ProofOfWorkDifficulty
+}
+
+// HandleChallenge issues a new challenge. Without proof of work the
+// difficulty is 0 and no challenge is needed.
+func HandleChallenge(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+
+	difficulty := ProofOfWorkDifficulty()
+	if difficulty <= 0 {
+		c.JSON(http.StatusOK, ChallengeResponse{})
+		return
+	}
+
+	expiresAt := time.Now().Add(challengeLifetime).UTC().Truncate(time.Second)
+	random := make([]byte, 16)
+	rand.Read(random)
+
+	payload := fmt.Sprintf("%d.%d.%s", expiresAt.Unix(), difficulty, hex.EncodeToString(random))
+	c.JSON(http.StatusOK, ChallengeResponse{
+		Challenge:  payload + "." + sign(payload),
+		Difficulty: difficulty,
+		ExpiresAt:  &expiresAt,
+	})
+}
+
+// VerifyProof checks a solved challenge if proof of work is enabled. Every
+// challenge can only be used once.
+func VerifyProof(proof Proof) error {
+	minDifficulty := ProofOfWorkDifficulty()
+	if minDifficulty <= 0 {
+		return nil
+	}
+
+	parts := strings.Split(proof.Challenge, ".")
+	if len(parts) != 4 {
+		return ErrInvalidChallenge
+	}
+	payload := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(sign(payload))) {
+		return ErrInvalidChallenge
+	}
+
+	expires, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || time.Now().After(time.Unix(expires, 0)) {
+		return ErrInvalidChallenge
+	}
+	// Challenges issued before the difficulty was raised are not accepted
+	difficulty, err := strconv.Atoi(parts[1])
+	if err != nil || difficulty < minDifficulty {
+		return ErrInvalidChallenge
+	}
+
+	hash := sha256.Sum256([]byte(proof.Challenge + ":" + proof.Nonce))
+	if leadingZeroBits(hash[:]) < difficulty {
+		return ErrInvalidProof
+	}
+
+	return markUsed(proof.Challenge, time.Unix(expires, 0))
+}
+
+func markUsed(challenge string, expires time.Time) error {
+	usedMu.Lock()
+	defer usedMu.Unlock()
+
+	now := time.Now()
+	for key, expiry := range used {
+		if now.After(expiry) {
+			delete(used, key)
+		}
+	}
+
+	if _, ok := used[challenge]; ok {
+		return ErrChallengeUsed
+	}
+	used[challenge] = expires
+	return nil
+}
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, challengeKey)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func leadingZeroBits(hash []byte) int {
+	count := 0
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
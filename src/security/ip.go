@@ -0,0 +1,152 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"gorm.io/gorm"
+)
+
+// The current salt and the one before it, newest first. Hashes made with the
+// previous salt still match until the next rotation.
+var (
+	salts      []models.IPHashSalt
+	saltsMutex sync.RWMutex
+)
+
+// IPHash is an IP address hashed with one salt version
+type IPHash struct {
+	Hash    string
+	Version uint
+}
+
+// HashIP pseudonymizes an IP address with the current salt, so visitors can
+// be recognized without storing their address
+func HashIP(ip string) string {
+	return HashIPVersions(ip)[0].Hash
+}
+
+// HashIPVersions hashes an IP address with the current and the previous salt,
+// current first. Lookups have to match any of them during the rotation window.
+func HashIPVersions(ip string) []IPHash {
+	saltsMutex.RLock()
+	defer saltsMutex.RUnlock()
+
+	if len(salts) == 0 {
+		return []IPHash{{Hash: hashWithSalt(ip, config.Data.Security.IPHashSalt)}}
+	}
+
+	hashes := make([]IPHash, 0, len(salts))
+	for _, salt := range salts {
+		hashes = append(hashes, IPHash{Hash: hashWithSalt(ip, salt.Salt), Version: salt.ID})
+	}
+	return hashes
+}
+
+// Hashes returns the hash strings, e.g. for an IN query
+func Hashes(hashes []IPHash) []string {
+	result := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		result = append(result, hash.Hash)
+	}
+	return result
+}
+
+// OldestSaltVersion returns the oldest salt version still in use. Hashes of
+// older versions can no longer be matched.
+func OldestSaltVersion() uint {
+	saltsMutex.RLock()
+	defer saltsMutex.RUnlock()
+
+	if len(salts) == 0 {
+		return 0
+	}
+	return salts[len(salts)-1].ID
+}
+
+// LoadIPHashSalts loads the salts from the database. The configured salt
+// becomes the first version, so existing hashes keep matching. Hashes stored
+// before salts were versioned are assigned to it, so the retention can clear
+// them once it was rotated out.
+func LoadIPHashSalts() {
+	var count int64
+	database.Db.Model(&models.IPHashSalt{}).Count(&count)
+	if count == 0 {
+		err := database.Db.Transaction(func(tx *gorm.DB) error {
+			salt := models.IPHashSalt{Salt: config.Data.Security.IPHashSalt}
+			if err := tx.Create(&salt).Error; err != nil {
+				return err
+			}
+			for _, model := range []interface{}{&models.NativeLike{}, &models.Comment{}} {
+				if err := tx.Model(model).Where("salt_version IS NULL OR salt_version = 0").Update("salt_version", salt.ID).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("Error storing IP hash salt: %v", err)
+			return
+		}
+	}
+
+	loadSalts()
+}
+
+// RotateIPHashSalt creates a new salt once the current one is older than the
+// configured rotation interval. Only the previous salt is kept.
+func RotateIPHashSalt() {
+	days := config.Data.Security.IPHashSaltRotationDays
+	if days <= 0 {
+		return
+	}
+
+	saltsMutex.RLock()
+	due := len(salts) > 0 && time.Since(salts[0].CreatedAt) >= time.Duration(days)*24*time.Hour
+	saltsMutex.RUnlock()
+	if !due {
+		return
+	}
+
+	salt := models.IPHashSalt{Salt: randomSalt()}
+	if err := database.Db.Create(&salt).Error; err != nil {
+		log.Printf("Error storing IP hash salt: %v", err)
+		return
+	}
+	if err := database.Db.Where("id < (SELECT MAX(id) FROM ip_hash_salts WHERE id < ?)", salt.ID).Delete(&models.IPHashSalt{}).Error; err != nil {
+		log.Printf("Error deleting old IP hash salts: %v", err)
+	}
+
+	loadSalts()
+	log.Printf("Rotated IP hash salt to version %d", salt.ID)
+}
+
+func loadSalts() {
+	var loaded []models.IPHashSalt
+	if err := database.Db.Order("id DESC").Limit(2).Find(&loaded).Error; err != nil {
+		log.Printf("Error loading IP hash salts: %v", err)
+		return
+	}
+
+	saltsMutex.Lock()
+	salts = loaded
+	saltsMutex.Unlock()
+}
+
+func hashWithSalt(ip string, salt string) string {
+	hash := sha256.Sum256([]byte(ip + salt))
+	return hex.EncodeToString(hash[:])
+}
+
+func randomSalt() string {
+	salt := make([]byte, 32)
+	rand.Read(salt)
+	return hex.EncodeToString(salt)
+}
@@ -0,0 +1,28 @@
+package security
+
+import (
+	"log"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultTrustedProxies are the private networks the bundled nginx container
+// connects from. The backend port itself is not published.
+var defaultTrustedProxies = []string{"127.0.0.0/8", "::1/128", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"}
+
+// TrustProxies makes ClientIP read the X-Real-IP header set by the reverse
+// proxy, but only for requests coming from a trusted proxy. All other requests
+// use the connection address, so clients cannot choose their own IP and get
+// around rate limits and like de-duplication.
+func TrustProxies(router *gin.Engine) {
+	proxies := config.Data.Security.TrustedProxies
+	if len(proxies) == 0 {
+		proxies = defaultTrustedProxies
+	}
+
+	router.RemoteIPHeaders = []string{"X-Real-IP"}
+	if err := router.SetTrustedProxies(proxies); err != nil {
+		log.Fatalf("Invalid trusted proxies: %v", err)
+	}
+}
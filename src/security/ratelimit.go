@@ -0,0 +1,71 @@
+package security
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Stale windows are swept at most this often
+const sweepInterval = time.Minute
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// limiter counts requests per key in fixed windows
+type limiter struct {
+	mu        sync.Mutex
+	limit     int
+	length    time.Duration
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+// allow counts a request and returns when the key may retry if it is over the limit
+func (l *limiter) allow(key string, now time.Time) (bool, time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > sweepInterval {
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.length {
+				delete(l.windows, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.length {
+		w = &window{start: now}
+		l.windows[key] = w
+	}
+
+	w.count++
+	if w.count > l.limit {
+		return false, w.start.Add(l.length)
+	}
+	return true, time.Time{}
+}
+
+// RateLimit allows limit requests per window and IP hash. Each call creates
+// its own counters, so routes sharing a budget must share the middleware.
+func RateLimit(limit int, length time.Duration) gin.HandlerFunc {
+	l := &limiter{limit: limit, length: length, windows: make(map[string]*window)}
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		allowed, retry := l.allow(HashIP(c.ClientIP()), now)
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(retry.Sub(now).Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+			return
+		}
+		c.Next()
+	}
+}
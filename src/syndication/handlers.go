@@ -0,0 +1,64 @@
+package syndication
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const cacheControl = "public, max-age=300"
+
+const maxBatchSize = 100
+
+// HandleGetSyndication returns the publications of a single item
+func HandleGetSyndication(c *gin.Context) {
+	itemName := c.Param("item_name")
+
+	links, err := LoadLinks([]string{itemName})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch syndication links"})
+		return
+	}
+
+	result := links[itemName]
+	if result == nil {
+		result = []Link{}
+	}
+
+	c.Header("Cache-Control", cacheControl)
+	c.JSON(http.StatusOK, gin.H{
+		"itemName": itemName,
+		"links":    result,
+	})
+}
+
+// HandleBatchSyndication returns the publications of a page of items, keyed by
+// item name. Query: ?item=First&item=Second
+func HandleBatchSyndication(c *gin.Context) {
+	itemNames := c.QueryArray("item")
+	if len(itemNames) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one item parameter required"})
+		return
+	}
+	if len(itemNames) > maxBatchSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Too many items"})
+		return
+	}
+
+	links, err := LoadLinks(itemNames)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch syndication links"})
+		return
+	}
+
+	result := make(map[string][]Link)
+	for _, name := range itemNames {
+		result[name] = links[name]
+		if result[name] == nil {
+			result[name] = []Link{}
+		}
+	}
+
+	c.Header("Cache-Control", cacheControl)
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,73 @@
+package syndication
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+)
+
+var instagramGraphURL = "https://graph.instagram.com/v22.0/"
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// FetchInstagramPermalink asks the Graph API for the public URL of a media object.
+func FetchInstagramPermalink(mediaID string, accessToken string) (string, error) {
+	endpoint := fmt.Sprintf("%s%s?fields=permalink&access_token=%s", instagramGraphURL, url.PathEscape(mediaID), url.QueryEscape(accessToken))
+
+	resp, err := httpClient.Get(endpoint)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("graph API returned status %d", resp.StatusCode)
+	}
+
+	var media struct {
+		Permalink string `json:"permalink"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&media); err != nil {
+		return "", err
+	}
+	if media.Permalink == "" {
+		return "", fmt.Errorf("no permalink for media %s", mediaID)
+	}
+	return media.Permalink, nil
+}
+
+// ResolveInstagramPermalinks stores the permalink of Instagram publications
+// that only have a media ID, e.g. ones published before permalinks were recorded.
+func ResolveInstagramPermalinks() {
+	for _, target := range config.Data.Targets {
+		if target.Platform == "instagram" && target.AccessToken != "" {
+			resolveInstagramPermalinks(target)
+		}
+	}
+}
+
+func resolveInstagramPermalinks(target config.Target) {
+	var items []models.AutoUploadItem
+	if err := database.Db.
+		Where("target_name = ? AND post_id IS NOT NULL AND post_id != '' AND (post_url IS NULL OR post_url = '')", target.Name).
+		Find(&items).Error; err != nil {
+		log.Printf("Error loading Instagram publications: %v", err)
+		return
+	}
+
+	for _, item := range items {
+		permalink, err := FetchInstagramPermalink(*item.PostId, target.AccessToken)
+		if err != nil {
+			log.Printf("Could not resolve Instagram permalink for %s: %v", item.ItemName, err)
+			continue
+		}
+		database.Db.Model(&item).Update("post_url", permalink)
+	}
+}
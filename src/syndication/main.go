@@ -0,0 +1,66 @@
+package syndication
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+)
+
+// Link points to a copy of an item on another platform
+type Link struct {
+	Platform    string    `json:"platform"`
+	TargetName  string    `json:"targetName,omitempty"`
+	URL         string    `json:"url,omitempty"`
+	PublishedAt time.Time `json:"publishedAt"`
+}
+
+// CanonicalURL returns the public web URL of a publication, or an empty
+// string if it is not known (yet).
+func CanonicalURL(item models.AutoUploadItem) string {
+	if item.PostUrl == nil || *item.PostUrl == "" {
+		return ""
+	}
+	postURL := *item.PostUrl
+
+	// Bluesky stores the AT URI
+	if strings.HasPrefix(postURL, "at://") {
+		return BlueskyWebURL(postURL)
+	}
+
+	if parsed, err := url.Parse(postURL); err == nil && (parsed.Scheme == "https" || parsed.Scheme == "http") {
+		return postURL
+	}
+	return ""
+}
+
+// BlueskyWebURL converts at://<did>/app.bsky.feed.post/<rkey> to the bsky.app URL
+func BlueskyWebURL(atURI string) string {
+	parts := strings.Split(strings.TrimPrefix(atURI, "at://"), "/")
+	if !strings.HasPrefix(atURI, "at://") || len(parts) != 3 || parts[1] != "app.bsky.feed.post" {
+		return ""
+	}
+	return "https://bsky.app/profile/" + parts[0] + "/post/" + parts[2]
+}
+
+// LoadLinks returns the syndication links of the given items, keyed by item name.
+// Items without publications are omitted.
+func LoadLinks(itemNames []string) (map[string][]Link, error) {
+	var publications []models.AutoUploadItem
+	if err := database.Db.Where("item_name IN ?", itemNames).Order("created_at").Find(&publications).Error; err != nil {
+		return nil, err
+	}
+
+	links := make(map[string][]Link)
+	for _, publication := range publications {
+		links[publication.ItemName] = append(links[publication.ItemName], Link{
+			Platform:    publication.Platform,
+			TargetName:  publication.TargetName,
+			URL:         CanonicalURL(publication),
+			PublishedAt: publication.CreatedAt,
+		})
+	}
+	return links, nil
+}
@@ -24,13 +24,28 @@ func BroadcastNotification(message models.Notification) {
     // Send to each
     for _, sub := range subscriptions {
         if err := SendNotification(sub, message); err != nil {
-            log.Printf("Failed to send to user %s: %v", sub.ID, err)
+            log.Printf("Failed to send to user %d: %v", sub.ID, err)
         } else {
-            log.Printf("Notification sent to user %s", sub.ID)
+            log.Printf("Notification sent to user %d", sub.ID)
         }
     }
 }
 
+// NotifyAdmins sends a notification to the admin subscriptions only
+func NotifyAdmins(message models.Notification) {
+	var subscriptions []models.NotificationSubscription
+	if err := database.Db.Where("admin = ?", true).Find(&subscriptions).Error; err != nil {
+		log.Printf("Error loading admin subscriptions: %v", err)
+		return
+	}
+
+	for _, sub := range subscriptions {
+		if err := SendNotification(sub, message); err != nil {
+			log.Printf("Failed to send admin notification to %d: %v", sub.ID, err)
+		}
+	}
+}
+
 func SendNotification(subscription models.NotificationSubscription, message models.Notification) error {
 	sub := webpush.Subscription{
 		Endpoint: subscription.Endpoint,
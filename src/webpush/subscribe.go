@@ -20,6 +20,16 @@ type SubscriptionKeys struct {
 }
 
 func SubscribeHandler() gin.HandlerFunc {
+	return subscribeHandler(false)
+}
+
+// AdminSubscribeHandler registers a subscription that also receives admin
+// notifications, e.g. about new comments
+func AdminSubscribeHandler() gin.HandlerFunc {
+	return subscribeHandler(true)
+}
+
+func subscribeHandler(admin bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req SubscriptionRequest
 
@@ -33,6 +43,7 @@ func SubscribeHandler() gin.HandlerFunc {
 			ExpirationTime: req.ExpirationTime,
 			Auth:           req.Keys.Auth,
 			P256dh:         req.Keys.P256dh,
+			Admin:          admin,
 		}
 
 		// Create or update (based on endpoint)
@@ -42,6 +53,14 @@ func SubscribeHandler() gin.HandlerFunc {
 			return
 		}
 
+		// An existing reader subscription of the admin's browser is promoted
+		if admin && !sub.Admin {
+			if err := database.Db.Model(&sub).Update("admin", true).Error; err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save subscription"})
+				return
+			}
+		}
+
 		c.JSON(http.StatusOK, gin.H{"message": "subscription saved"})
 	}
 }
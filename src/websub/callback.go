@@ -0,0 +1,135 @@
+package websub
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"github.com/gin-gonic/gin"
+)
+
+const maxNotificationSize = 10 << 20
+
+var signatureAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// HandleVerification answers the hub's intent verification for (un)subscribe requests
+func HandleVerification(c *gin.Context) {
+	sub, ok := loadSubscription(c)
+	if !ok {
+		return
+	}
+
+	mode := c.Query("hub.mode")
+	topic := c.Query("hub.topic")
+
+	if mode == "denied" {
+		log.Printf("WebSub subscription for %s denied: %s", sub.FeedName, c.Query("hub.reason"))
+		c.Status(http.StatusOK)
+		return
+	}
+
+	if topic != sub.Topic || (mode != "subscribe" && mode != "unsubscribe") {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	now := time.Now()
+	if mode == "subscribe" {
+		sub.VerifiedAt = &now
+		if seconds, err := strconv.Atoi(c.Query("hub.lease_seconds")); err == nil {
+			expires := now.Add(time.Duration(seconds) * time.Second)
+			sub.LeaseExpiresAt = &expires
+		}
+	} else {
+		sub.VerifiedAt = nil
+		sub.LeaseExpiresAt = nil
+	}
+
+	if err := database.Db.Save(sub).Error; err != nil {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("Verified WebSub %s for %s", mode, sub.FeedName)
+	c.Data(http.StatusOK, "text/plain", []byte(c.Query("hub.challenge")))
+}
+
+// HandleNotification receives content distribution requests and syncs the feed
+func HandleNotification(c *gin.Context) {
+	sub, ok := loadSubscription(c)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxNotificationSize))
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	// Hubs expect a 2xx even for invalid signatures, the content is just ignored
+	if !validSignature(c.GetHeader("X-Hub-Signature"), body, sub.Secret) {
+		log.Printf("Ignoring WebSub notification for %s with invalid signature", sub.FeedName)
+		c.Status(http.StatusAccepted)
+		return
+	}
+
+	log.Printf("WebSub notification for %s, syncing", sub.FeedName)
+	inventory.SyncDatasourceInBackground(sub.FeedName)
+
+	c.Status(http.StatusAccepted)
+}
+
+func loadSubscription(c *gin.Context) (*models.WebSubSubscription, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return nil, false
+	}
+
+	var sub models.WebSubSubscription
+	if err := database.Db.First(&sub, id).Error; err != nil {
+		c.Status(http.StatusNotFound)
+		return nil, false
+	}
+	return &sub, true
+}
+
+// validSignature checks an X-Hub-Signature header of the form "sha256=<hex>"
+func validSignature(header string, body []byte, secret string) bool {
+	algorithm, signature, found := strings.Cut(header, "=")
+	if !found || secret == "" {
+		return false
+	}
+
+	newHash, ok := signatureAlgorithms[strings.ToLower(algorithm)]
+	if !ok {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
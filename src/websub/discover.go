@@ -0,0 +1,101 @@
+package websub
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// discover returns the hub and self URLs advertised by a feed, either in the
+// HTTP Link header or as <link rel="hub"> elements in the document.
+func discover(feedURL string) (hub string, self string, err error) {
+	resp, err := httpClient.Get(feedURL)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("feed returned status %d", resp.StatusCode)
+	}
+
+	for _, header := range resp.Header.Values("Link") {
+		for _, part := range strings.Split(header, ",") {
+			href, rel := parseLinkHeaderPart(part)
+			switch {
+			case hasRel(rel, "hub") && hub == "":
+				hub = href
+			case hasRel(rel, "self") && self == "":
+				self = href
+			}
+		}
+	}
+
+	decoder := xml.NewDecoder(io.LimitReader(resp.Body, 10<<20))
+	decoder.Strict = false
+	for hub == "" || self == "" {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "link" {
+			continue
+		}
+
+		var href, rel string
+		for _, attr := range element.Attr {
+			switch attr.Name.Local {
+			case "href":
+				href = attr.Value
+			case "rel":
+				rel = attr.Value
+			}
+		}
+
+		switch {
+		case hasRel(rel, "hub") && hub == "":
+			hub = href
+		case hasRel(rel, "self") && self == "":
+			self = href
+		}
+	}
+
+	if hub == "" {
+		return "", "", fmt.Errorf("no hub advertised by %s", feedURL)
+	}
+	if self == "" {
+		self = feedURL
+	}
+	return hub, self, nil
+}
+
+// parseLinkHeaderPart parses `<https://example.com>; rel="hub"`
+func parseLinkHeaderPart(part string) (href string, rel string) {
+	start := strings.Index(part, "<")
+	end := strings.Index(part, ">")
+	if start == -1 || end <= start {
+		return "", ""
+	}
+	href = part[start+1 : end]
+
+	for _, param := range strings.Split(part[end+1:], ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if found && strings.EqualFold(key, "rel") {
+			rel = strings.Trim(value, `"`)
+		}
+	}
+	return href, rel
+}
+
+func hasRel(rel string, name string) bool {
+	for _, value := range strings.Fields(rel) {
+		if strings.EqualFold(value, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package websub
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LNA-DEV/HomePageCompanion/config"
+	"github.com/LNA-DEV/HomePageCompanion/database"
+	"github.com/LNA-DEV/HomePageCompanion/inventory"
+	"github.com/LNA-DEV/HomePageCompanion/models"
+	"gorm.io/gorm"
+)
+
+const defaultLeaseSeconds = 7 * 24 * 60 * 60
+
+// renewBefore is how long before expiry a lease is renewed
+const renewBefore = 24 * time.Hour
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Enabled reports whether a public callback URL is configured.
+func Enabled() bool {
+	return config.Data.WebSub.CallbackBaseURL != ""
+}
+
+// RenewSubscriptions subscribes all rss datasources whose hub lease is missing
+// or about to expire.
+func RenewSubscriptions() {
+	if !Enabled() {
+		return
+	}
+
+	for _, source := range inventory.Datasources() {
+		if source.Type != config.DatasourceTypeRss {
+			continue
+		}
+
+		sub, err := findOrCreateSubscription(source)
+		if err != nil {
+			log.Printf("Error loading WebSub subscription for %s: %v", source.Name, err)
+			continue
+		}
+
+		if sub.LeaseExpiresAt != nil && time.Until(*sub.LeaseExpiresAt) > renewBefore {
+			continue
+		}
+
+		if err := subscribe(sub); err != nil {
+			log.Printf("WebSub subscription for %s failed: %v", source.Name, err)
+		}
+	}
+}
+
+func findOrCreateSubscription(source config.Datasource) (*models.WebSubSubscription, error) {
+	var sub models.WebSubSubscription
+	err := database.Db.Where("feed_url = ?", source.FeedURL).First(&sub).Error
+	if err == gorm.ErrRecordNotFound {
+		sub = models.WebSubSubscription{
+			FeedName: source.Name,
+			FeedURL:  source.FeedURL,
+			Secret:   generateSecret(),
+		}
+		if err := database.Db.Create(&sub).Error; err != nil {
+			return nil, err
+		}
+		return &sub, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if sub.FeedName != source.Name {
+		sub.FeedName = source.Name
+		database.Db.Save(&sub)
+	}
+	return &sub, nil
+}
+
+// subscribe discovers the hub of the feed and sends a subscription request.
+// The hub confirms asynchronously through the verification callback.
+func subscribe(sub *models.WebSubSubscription) error {
+	hub, topic, err := discover(sub.FeedURL)
+	if err != nil {
+		return err
+	}
+
+	sub.Hub = hub
+	sub.Topic = topic
+	if err := database.Db.Save(sub).Error; err != nil {
+		return err
+	}
+
+	leaseSeconds := config.Data.WebSub.LeaseSeconds
+	if leaseSeconds <= 0 {
+		leaseSeconds = defaultLeaseSeconds
+	}
+
+	form := url.Values{}
+	form.Set("hub.mode", "subscribe")
+	form.Set("hub.topic", topic)
+	form.Set("hub.callback", callbackURL(sub))
+	form.Set("hub.secret", sub.Secret)
+	form.Set("hub.lease_seconds", strconv.Itoa(leaseSeconds))
+
+	resp, err := httpClient.PostForm(hub, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("hub returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	log.Printf("Requested WebSub subscription for %s at %s", sub.FeedName, hub)
+	return nil
+}
+
+func callbackURL(sub *models.WebSubSubscription) string {
+	base := strings.TrimRight(config.Data.WebSub.CallbackBaseURL, "/")
+	return fmt.Sprintf("%s/api/websub/callback/%d", base, sub.ID)
+}
+
+func generateSecret() string {
+	bytes := make([]byte, 32)
+	rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
@@ -188,6 +188,24 @@ export interface FeedItem {
 	GUID: string;
 	Categories: Category[];
 	Authors: Author[];
+	ImageWidth: number;
+	ImageHeight: number;
+	ImageType: string;
+	ImageSize: number;
+	Exif: ImageExif;
+}
+
+export interface ImageExif {
+	CameraMake: string;
+	CameraModel: string;
+	LensModel: string;
+	FocalLength: number;
+	ExposureTime: string;
+	FNumber: number;
+	ISO: number;
+	CapturedAt: string | null;
+	Latitude: number | null;
+	Longitude: number | null;
 }
 
 export interface Category {
@@ -211,6 +229,7 @@ export interface PaginatedFeedItems {
 export interface AutoUploadItem {
 	ID: number;
 	Platform: string;
+	TargetName: string;
 	ItemName: string;
 	PostUrl: string | null;
 	VersionId: string | null;
@@ -224,6 +243,9 @@ export interface Interaction {
 	Platform: string;
 	TargetName: string;
 	LikeCount: number;
+	RepostCount: number;
+	ReplyCount: number;
+	QuoteCount: number;
 	CreatedAt: string;
 	UpdatedAt: string;
 }
@@ -243,6 +265,7 @@ export interface ItemLikes {
 export interface Subscriber {
 	id: number;
 	endpoint: string;
+	admin: boolean;
 	createdAt: string;
 }
 